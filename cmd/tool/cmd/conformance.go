@@ -0,0 +1,159 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/seeleteam/go-seele/conformance"
+	"github.com/spf13/cobra"
+)
+
+var (
+	corpusDir      string
+	generateVector bool
+	generateFrom   uint64
+	generateTo     uint64
+)
+
+var conformanceCmd = &cobra.Command{
+	Use:   "conformance",
+	Short: "run a value-transfer test-vector corpus against the scaffold Applier (not full consensus conformance)",
+	Long: `Runs every test vector in --corpus against an Applier and reports any
+mismatch between the vector's expected post-state and the actual one.
+
+This does NOT drive this repo's core state transition: core has no
+in-process entry point this tool can call, so the Applier wired in today
+(valueTransferApplier) only understands plain value transfers. A passing run
+exercises the corpus/runner machinery - file format, diffing, CLI plumbing -
+and nothing about consensus-level correctness. Treat this as scaffolding for
+a real conformance suite, not one; see valueTransferApplier's doc comment for
+what it does and doesn't check.
+
+--generate is not implemented: dumping a vector needs a full account
+pre/post-state for a block, which this tool's RPC surface doesn't expose.
+
+For example:
+	tool.exe conformance --corpus ./vectors`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if corpusDir == "" {
+			fmt.Println("--corpus is required")
+			os.Exit(1)
+		}
+
+		if generateVector {
+			runGenerate()
+			return
+		}
+
+		runConformance()
+	},
+}
+
+func runConformance() {
+	fmt.Println("NOTE: this only runs the value-transfer scaffold Applier, not this repo's core state transition - see `tool.exe conformance --help`")
+
+	vectors, err := conformance.LoadCorpus(corpusDir)
+	if err != nil {
+		fmt.Println("failed to load corpus: ", err)
+		os.Exit(1)
+	}
+
+	results := conformance.Run(vectors, valueTransferApplier{})
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("FAIL %s: %s\n", r.Name, r.Err)
+			continue
+		}
+
+		if !r.Passed {
+			failed++
+			fmt.Printf("FAIL %s\n", r.Name)
+			for _, d := range r.Diffs {
+				fmt.Printf("  %s\n", d)
+			}
+			continue
+		}
+
+		fmt.Printf("PASS %s\n", r.Name)
+	}
+
+	fmt.Printf("%d/%d vectors passed\n", len(results)-failed, len(results))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func runGenerate() {
+	// Generating a vector needs to dump full account pre/post-state for an
+	// arbitrary block, which this tool's RPC surface doesn't expose yet
+	// (seele_getBalance/seele_getAccountNonce are per-address, not a full
+	// state dump). Fail loudly rather than emitting an incomplete corpus.
+	fmt.Println("--generate needs a ChainReader backed by a state-dump RPC endpoint, which this node doesn't expose yet")
+	os.Exit(1)
+}
+
+// valueTransferApplier is the conformance package's reference Applier: it
+// understands plain value transfers (debit From, credit To, bump From's
+// nonce) and nothing else. It exists so the corpus+runner machinery itself
+// is exercised end-to-end; a full run against this repo's core state
+// transition replaces it once the conformance runner is driven in-process
+// rather than via this standalone CLI tool.
+type valueTransferApplier struct{}
+
+func (valueTransferApplier) Apply(pre conformance.State, msg conformance.Message) (*conformance.State, *conformance.Receipt, error) {
+	post := conformance.State{Accounts: make(map[string]conformance.Account, len(pre.Accounts))}
+	for addr, acc := range pre.Accounts {
+		post.Accounts[addr] = conformance.Account{
+			Balance: new(big.Int).Set(acc.Balance),
+			Nonce:   acc.Nonce,
+			Code:    acc.Code,
+		}
+	}
+
+	from := msg.From.ToHex()
+	to := msg.To.ToHex()
+
+	fromAcc, ok := post.Accounts[from]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown from account %s", from)
+	}
+
+	if fromAcc.Nonce != msg.Nonce {
+		return &post, &conformance.Receipt{Failed: true}, nil
+	}
+
+	if fromAcc.Balance.Cmp(msg.Amount) < 0 {
+		return &post, &conformance.Receipt{Failed: true}, nil
+	}
+
+	fromAcc.Balance = new(big.Int).Sub(fromAcc.Balance, msg.Amount)
+	fromAcc.Nonce++
+	post.Accounts[from] = fromAcc
+
+	toAcc := post.Accounts[to]
+	if toAcc.Balance == nil {
+		toAcc.Balance = big.NewInt(0)
+	}
+	toAcc.Balance = new(big.Int).Add(toAcc.Balance, msg.Amount)
+	post.Accounts[to] = toAcc
+
+	return &post, &conformance.Receipt{GasUsed: 0, Failed: false}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(conformanceCmd)
+
+	conformanceCmd.Flags().StringVarP(&corpusDir, "corpus", "c", "", "directory of JSON test vectors")
+	conformanceCmd.Flags().BoolVarP(&generateVector, "generate", "g", false, "generate a corpus from a live chain instead of running one (not implemented: exits with an error)")
+	conformanceCmd.Flags().Uint64VarP(&generateFrom, "from", "", 0, "first block height to generate a vector from")
+	conformanceCmd.Flags().Uint64VarP(&generateTo, "to", "", 0, "last block height to generate a vector from")
+}
@@ -0,0 +1,186 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/seeleteam/go-seele/cmd/util"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/scenario"
+	"github.com/seeleteam/go-seele/txtracker"
+	"github.com/seeleteam/go-seele/walletpool"
+)
+
+// scenarioDriver implements scenario.Driver on top of the same walletpool
+// and clientList the legacy mode 1/2/3 loops use, so a scenario file can
+// drive the exact same node(s) the classic modes do.
+type scenarioDriver struct {
+	wallets *walletpool.Pool
+	funding *common.Address // keyfile account used to fund generated wallets
+}
+
+func newScenarioDriver(wallets *walletpool.Pool, funding *common.Address) *scenarioDriver {
+	return &scenarioDriver{wallets: wallets, funding: funding}
+}
+
+func (d *scenarioDriver) GenerateWallets(n int, shard uint) ([]*scenario.Wallet, error) {
+	wallets := make([]*scenario.Wallet, 0, n)
+
+	for i := 0; i < n; i++ {
+		addr, key := crypto.MustGenerateShardKeyPair(shard)
+		d.wallets.Add(*addr, key, shard, 0, big.NewInt(0))
+		wallets = append(wallets, &scenario.Wallet{Address: addr, Shard: shard})
+	}
+
+	return wallets, nil
+}
+
+func (d *scenarioDriver) FundWallets(wallets []*scenario.Wallet, amount *big.Int) error {
+	if d.funding == nil {
+		return fmt.Errorf("no funding account available to fund wallets")
+	}
+
+	for _, w := range wallets {
+		if err := d.fundOne(w, amount); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fundOne reserves d.funding and sends it amount to w. If d.funding is
+// already reserved elsewhere (e.g. the legacy mode 1/2/3 loops sharing this
+// same pool), it falls back to the next idle wallet Rotate hands out on w's
+// shard rather than failing the whole run. Rotate does not exclude w itself
+// or other wallets with no balance.
+func (d *scenarioDriver) fundOne(w *scenario.Wallet, amount *big.Int) error {
+	from := *d.funding
+	nonce, key, err := d.wallets.Reserve(from)
+	if err != nil {
+		fallback, rerr := d.wallets.Rotate(w.Shard)
+		if rerr != nil {
+			return fmt.Errorf("reserve faucet nonce: %s (no fallback wallet on shard %d: %s)", err, w.Shard, rerr)
+		}
+
+		from = fallback.Address
+		nonce, key, err = d.wallets.Reserve(from)
+		if err != nil {
+			return fmt.Errorf("reserve fallback funding nonce on shard %d: %s", w.Shard, err)
+		}
+	}
+
+	tx, err := util.GenerateTx(key, *w.Address, amount, big.NewInt(1), nonce, nil)
+	if err != nil {
+		d.wallets.Release(from, nonce)
+		return fmt.Errorf("build funding tx for %s: %s", w.Address.ToHex(), err)
+	}
+
+	start := time.Now()
+	client := getClient(*w.Address)
+	ok, err := util.SendTx(client, tx)
+	clients.Release(client, time.Since(start), err)
+	if !ok || err != nil {
+		d.wallets.Release(from, nonce)
+		return fmt.Errorf("fund %s: %s", w.Address.ToHex(), err)
+	}
+
+	d.wallets.Confirm(from, nonce, tx.Hash)
+	return nil
+}
+
+func (d *scenarioDriver) SendTransfers(from []*scenario.Wallet, to []*scenario.Wallet, amount *big.Int) ([]common.Hash, error) {
+	if len(from) == 0 || len(to) == 0 {
+		return nil, fmt.Errorf("send_transfers needs at least one from and one to wallet")
+	}
+
+	hashes := make([]common.Hash, 0, len(from))
+	for i, f := range from {
+		t := to[i%len(to)]
+
+		hash, err := d.sendFrom(*f.Address, *t.Address, amount, nil)
+		if err != nil {
+			return hashes, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (d *scenarioDriver) SendContractCalls(from []*scenario.Wallet, to common.Address, payload []byte) ([]common.Hash, error) {
+	hashes := make([]common.Hash, 0, len(from))
+	for _, f := range from {
+		hash, err := d.sendFrom(*f.Address, to, big.NewInt(0), payload)
+		if err != nil {
+			return hashes, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (d *scenarioDriver) sendFrom(from, to common.Address, amount *big.Int, payload []byte) (common.Hash, error) {
+	nonce, key, err := d.wallets.Reserve(from)
+	if err != nil {
+		return common.EmptyHash, fmt.Errorf("reserve nonce for %s: %s", from.ToHex(), err)
+	}
+
+	tx, err := util.GenerateTx(key, to, amount, big.NewInt(1), nonce, payload)
+	if err != nil {
+		d.wallets.Release(from, nonce)
+		return common.EmptyHash, fmt.Errorf("build tx from %s: %s", from.ToHex(), err)
+	}
+
+	start := time.Now()
+	client := getClient(from)
+	ok, err := util.SendTx(client, tx)
+	clients.Release(client, time.Since(start), err)
+	if !ok || err != nil {
+		d.wallets.Release(from, nonce)
+		return common.EmptyHash, fmt.Errorf("send tx from %s: %s", from.ToHex(), err)
+	}
+
+	d.wallets.Confirm(from, nonce, tx.Hash)
+	return tx.Hash, nil
+}
+
+// AwaitInclusion waits on the shared txTracker for every hash, rather than
+// running its own polling loop.
+func (d *scenarioDriver) AwaitInclusion(ctx context.Context, hashes []common.Hash, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results, err := txTracker.AwaitN(ctx, hashes, txtracker.Options{})
+	if err != nil {
+		return err
+	}
+
+	for r := range results {
+		if r.Err != nil {
+			return fmt.Errorf("tx %s: %s", r.Hash.ToHex(), r.Err)
+		}
+	}
+
+	return nil
+}
+
+func (d *scenarioDriver) Balance(address common.Address) (*big.Int, error) {
+	amount, ok := getBalance(address)
+	if !ok {
+		return nil, fmt.Errorf("failed to get balance of %s", address.ToHex())
+	}
+
+	return big.NewInt(int64(amount)), nil
+}
@@ -6,19 +6,28 @@
 package cmd
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"math/rand"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/seeleteam/go-seele/cmd/util"
 	"github.com/seeleteam/go-seele/common"
 	"github.com/seeleteam/go-seele/crypto"
+	"github.com/seeleteam/go-seele/instrumentation"
 	"github.com/seeleteam/go-seele/rpc"
+	"github.com/seeleteam/go-seele/rpcpool"
+	"github.com/seeleteam/go-seele/scenario"
+	"github.com/seeleteam/go-seele/txtracker"
+	"github.com/seeleteam/go-seele/walletpool"
 	"github.com/spf13/cobra"
 	"github.com/seeleteam/go-seele/api"
 )
@@ -26,22 +35,64 @@ import (
 var tps int
 var debug bool
 
+// metricsAddr, when set, starts an HTTP /metrics endpoint serving the
+// counters and histograms in the instrumentation package.
+var metricsAddr string
+
+// faucetAddress, when set, designates a keyfile account as the faucet
+// EnsureFunded refills generated recipients from once their cached balance
+// drops below faucetThreshold. Left empty, no refill happens and a
+// generated wallet that runs dry just stops sending, same as before the
+// walletpool faucet was added.
+var faucetAddress string
+var faucetThreshold int64
+var faucetRefill int64
+
+// reconcileInterval, when non-zero, runs wallets.Reconcile on this cadence
+// in the background so long-running load tests don't drift from the node's
+// view of nonce/balance after a missed or dropped RPC response.
+var reconcileInterval time.Duration
+
 // send tx mode
 // mode 1: send tx and check the txs periodically. add them back to balances after confirmed
 // mode 2: send tx with amount 1 and don't care about new balances
 // mode 3: split tx to 3 parts. send tx with full amount and replace old balances with new balances
 var mode int
 
+// scenarioFile, when set, switches sendtx from the hardcoded mode 1/2/3
+// loops to running the task DAG described by the given scenario file.
+var scenarioFile string
+
 var wg = sync.WaitGroup{}
 
+// rpcStrategy picks how clients are built into the rpcpool.Pool.
+var rpcStrategy string
+
+// clients replaces the old getRandClient map-iteration with a pool that can
+// be health-checked and pinned by shard/address.
+var clients *rpcpool.Pool
+
+// wallets owns every address's private key, nonce and cached balance. It
+// replaces the privateKey/nonce fields that used to live directly on
+// balance, which let loopSendMode1_2 and loopCheckMode1 race over the same
+// *balance pointer.
+var wallets = walletpool.New()
+
+// faucet is non-nil once --faucet-address is set, and is consulted by send
+// before every reservation so a generated wallet that drops below threshold
+// gets topped up instead of just stalling once its balance runs out.
+var faucet *walletpool.Faucet
+
 type balance struct {
-	address    *common.Address
-	privateKey *ecdsa.PrivateKey
-	amount     int
-	shard      uint
-	nonce      uint64
-	tx         *common.Hash
-	packed     bool
+	address *common.Address
+	amount  int
+	shard   uint
+	tx      *common.Hash
+	packed  bool
+
+	// sentFrom is the address tx was sent from, so loopCheckMode1 can
+	// Forget tx from that wallet's in-flight set once it confirms.
+	sentFrom *common.Address
 }
 
 var sendTxCmd = &cobra.Command{
@@ -50,9 +101,44 @@ var sendTxCmd = &cobra.Command{
 	Long: `For example:
 	tool.exe sendtx`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if metricsAddr != "" {
+			instrumentation.StartServer(metricsAddr)
+		}
+
+		txLimiter = rate.NewLimiter(rate.Limit(tps), 1)
+
 		initClient()
+		clients = rpcpool.New(clientList, rpcpool.Strategy(rpcStrategy))
+		clients.StartHealthProbe(context.Background(), 30*time.Second, func(client *rpc.Client) error {
+			_, err := util.GetInfo(client)
+			return err
+		})
+
 		balanceList := initAccount(threads)
 
+		if reconcileInterval > 0 {
+			wallets.StartReconciler(context.Background(), rpcChainClient{}, reconcileInterval)
+		}
+
+		if faucetAddress != "" {
+			addr, err := common.HexToAddress(faucetAddress)
+			if err != nil {
+				fmt.Println("invalid --faucet-address: ", err)
+				os.Exit(1)
+			}
+
+			faucet = &walletpool.Faucet{
+				Address:      addr,
+				Threshold:    big.NewInt(faucetThreshold),
+				RefillAmount: big.NewInt(faucetRefill),
+			}
+		}
+
+		if scenarioFile != "" {
+			runScenario(balanceList)
+			return
+		}
+
 		fmt.Println("use mode ", mode)
 		fmt.Println("threads", threads)
 		fmt.Println("total balance ", len(balanceList))
@@ -67,6 +153,31 @@ var sendTxCmd = &cobra.Command{
 	},
 }
 
+// runScenario loads scenarioFile and executes it as a task DAG instead of
+// one of the legacy loopSendMode* loops. It exits the process non-zero on
+// any task failure, e.g. a failed assert_balance, so this can be wired into
+// CI-driven testnets.
+func runScenario(balanceList []*balance) {
+	config, err := scenario.LoadConfig(scenarioFile)
+	if err != nil {
+		fmt.Println("failed to load scenario: ", err)
+		os.Exit(1)
+	}
+
+	var funding *common.Address
+	if len(balanceList) > 0 {
+		funding = balanceList[0].address
+	}
+
+	driver := newScenarioDriver(wallets, funding)
+	runner := scenario.NewRunner(config, driver, os.Stdout)
+
+	if err := runner.Run(context.Background()); err != nil {
+		fmt.Println("scenario failed: ", err)
+		os.Exit(1)
+	}
+}
+
 func StartSend(balanceList []*balance, threadNum int) {
 	lock := &sync.Mutex{}
 	if mode == 3 {
@@ -83,8 +194,24 @@ func StartSend(balanceList []*balance, threadNum int) {
 	}
 }
 
-var tpsStartTime time.Time
-var tpsCount = 0
+// txLimiter enforces the combined --tps target across every sending
+// goroutine (mode 3's three splits, or every loopSendMode1_2 thread),
+// replacing the hand-rolled tpsCount/tpsStartTime ticker math those loops
+// used to keep separately (and which raced, since both read/wrote the same
+// package-level counters from multiple goroutines). It's built from the
+// --tps flag once Run parses it.
+var txLimiter *rate.Limiter
+
+// reportObserved prints the observed tps against target tps once a second,
+// replacing the `send txs N at thread N` counters the hand-rolled throttle
+// used to print as a side effect of its own bookkeeping.
+func reportObserved(label string, sent *int, windowStart *time.Time) {
+	if elapsed := time.Since(*windowStart); elapsed >= time.Second {
+		fmt.Printf("%s: observed tps %.2f, target tps %d\n", label, float64(*sent)/elapsed.Seconds(), tps)
+		*sent = 0
+		*windowStart = time.Now()
+	}
+}
 
 func loopSendMode3(balanceList []*balance) {
 	defer wg.Done()
@@ -92,12 +219,14 @@ func loopSendMode3(balanceList []*balance) {
 	balances := newBalancesList(balanceList, 3, false)
 	nextBalances := newBalancesList(balanceList, 3, true)
 
-	tpsStartTime = time.Now()
+	sent := 0
+	windowStart := time.Now()
+
 	// send tx periodically
 	for {
-		SendMode3(balances[0], nextBalances[0])
-		SendMode3(balances[1], nextBalances[1])
-		SendMode3(balances[2], nextBalances[2])
+		SendMode3(balances[0], nextBalances[0], &sent, &windowStart)
+		SendMode3(balances[1], nextBalances[1], &sent, &windowStart)
+		SendMode3(balances[2], nextBalances[2], &sent, &windowStart)
 	}
 }
 
@@ -123,37 +252,38 @@ func newBalancesList(balanceList []*balance, splitNum int, copyValue bool) [][]*
 	return balances
 }
 
-func SendMode3(current []*balance, next []*balance) {
+func SendMode3(current []*balance, next []*balance, sent *int, windowStart *time.Time) {
 	copy(current, next)
 	for i, b := range current {
+		txLimiter.Wait(context.Background())
+
 		newBalance := send(b)
 		if debug {
-			fmt.Printf("send tx %s, account %s, nonce %d\n", newBalance.tx.ToHex(), b.address.ToHex(), b.nonce-1)
+			fmt.Printf("send tx %s, account %s\n", newBalance.tx.ToHex(), b.address.ToHex())
 		}
 
 		next[i] = newBalance
 
-		tpsCount++
-		if tpsCount == tps {
-			fmt.Printf("send txs %d, [%d]\n", tpsCount, i)
-			elapse := time.Now().Sub(tpsStartTime)
-			if elapse < time.Second {
-				time.Sleep(time.Second - elapse)
-			}
-
-			tpsCount = 0
-			tpsStartTime = time.Now()
-		}
+		*sent++
+		reportObserved("mode 3", sent, windowStart)
 	}
 }
 
 var txCh = make(chan *balance, 100000)
 
+// submitTimes records when each submitted tx's hash was sent, keyed by
+// common.Hash, so loopCheckMode1 can observe seele_sendtx_inclusion_seconds
+// once a tx confirms. This is the instrumentation point
+// getIncludedAndPendingBalance used to serve before it was replaced by
+// txTracker-backed waiters.
+var submitTimes sync.Map
+
 func loopSendMode1_2(balanceList []*balance, lock *sync.Mutex, threadNum int) {
 	defer wg.Done()
 
-	count := 0
-	tpsStartTime = time.Now()
+	sent := 0
+	windowStart := time.Now()
+	label := fmt.Sprintf("thread %d", threadNum)
 
 	// send tx periodically
 	for {
@@ -164,6 +294,8 @@ func loopSendMode1_2(balanceList []*balance, lock *sync.Mutex, threadNum int) {
 		lock.Unlock()
 
 		for _, b := range copyBalances {
+			txLimiter.Wait(context.Background())
+
 			newBalance := send(b)
 			if mode == 1 {
 				if newBalance.amount > 0 {
@@ -171,17 +303,8 @@ func loopSendMode1_2(balanceList []*balance, lock *sync.Mutex, threadNum int) {
 				}
 			}
 
-			count++
-			if count == tps {
-				fmt.Printf("send txs %d at thread %d\n", count, threadNum)
-				elapse := time.Now().Sub(tpsStartTime)
-				if elapse < time.Second {
-					time.Sleep(time.Second - elapse)
-				}
-
-				count = 0
-				tpsStartTime = time.Now()
-			}
+			sent++
+			reportObserved(label, &sent, &windowStart)
 		}
 
 		lock.Lock()
@@ -189,6 +312,11 @@ func loopSendMode1_2(balanceList []*balance, lock *sync.Mutex, threadNum int) {
 		for _, b := range balanceList {
 			if b.amount > 0 {
 				nextBalanceList = append(nextBalanceList, b)
+			} else {
+				// Spent down to zero: this address will never send or
+				// receive again, so drop it from the pool instead of
+				// leaking a wallet entry for the life of the process.
+				wallets.Remove(*b.address)
 			}
 		}
 		balanceList = nextBalanceList
@@ -196,78 +324,47 @@ func loopSendMode1_2(balanceList []*balance, lock *sync.Mutex, threadNum int) {
 	}
 }
 
+// confirmations is how many blocks must land on top of a tx's including
+// block before loopCheckMode1 treats it as confirmed and recycles its
+// balance, replacing the old fixed 2-minute wait with something that tracks
+// actual chain progress.
+const confirmations = 4
+
+// loopCheckMode1 waits for each sent tx to be included and confirmed, then
+// adds its balance back to balanceList. It used to poll every pending tx on
+// two 30s tickers (checkPack/confirm); now each tx registers its own
+// txtracker waiter, so a tx that lands quickly is recycled quickly instead
+// of waiting for the next tick.
 func loopCheckMode1(balanceList []*balance, lock *sync.Mutex) {
 	defer wg.Done()
-	toPackedBalanceList := make([]*balance, 0)
-	toConfirmBalanceList := make(map[time.Time][]*balance)
 
-	var confirmTime = 2 * time.Minute
-	checkPack := time.NewTicker(30 * time.Second)
-	confirm := time.NewTicker(30 * time.Second)
-	for {
-		select {
-		case b := <-txCh:
-			toPackedBalanceList = append(toPackedBalanceList, b)
-		case <-checkPack.C:
-			included, pending := getIncludedAndPendingBalance(toPackedBalanceList)
-			toPackedBalanceList = pending
-
-			fmt.Printf("to packed balance: %d, new: %d\n", len(toPackedBalanceList), len(pending))
-			toConfirmBalanceList[time.Now()] = included
-			toPackedBalanceList = pending
-		case <-confirm.C:
-			for key, value := range toConfirmBalanceList {
-				duration := time.Now().Sub(key)
-				if duration > confirmTime {
-
-					lock.Lock()
-					balanceList = append(balanceList, value...)
-					fmt.Printf("add confirmed balance %d, new: %d\n", len(value), len(balanceList))
-					lock.Unlock()
-
-					delete(toConfirmBalanceList, key)
-				}
+	for b := range txCh {
+		go func(b *balance) {
+			ch, err := txTracker.Await(context.Background(), *b.tx, txtracker.Options{Confirmations: confirmations})
+			if err != nil {
+				fmt.Println("failed to await tx ", b.tx.ToHex(), ": ", err)
+				return
 			}
-		}
-	}
-}
 
-func getIncludedAndPendingBalance(balances []*balance) ([]*balance, []*balance) {
-	include := make([]*balance, 0)
-	pending := make([]*balance, 0)
-	for _, b := range balances {
-		if b.tx == nil {
-			continue
-		}
+			<-ch
 
-		result := getTx(*b.address, *b.tx)
-		if len(result) > 0 {
-			if result["status"] == "block" {
-				include = append(include, b)
-			} else if result["status"] == "pool" {
-				pending = append(pending, b)
+			if b.sentFrom != nil {
+				wallets.Forget(*b.sentFrom, *b.tx)
 			}
 
-			if debug {
-				fmt.Printf("got tx success %s from %s nonce %.0f status %s amount %.0f\n", b.tx.ToHex(), result["from"],
-					result["accountNonce"], result["status"], result["amount"])
+			shard := strconv.Itoa(int(b.shard))
+			instrumentation.Confirmed.WithLabelValues(shard).Inc()
+			if submitted, ok := submitTimes.Load(*b.tx); ok {
+				instrumentation.InclusionSeconds.WithLabelValues(shard).Observe(time.Since(submitted.(time.Time)).Seconds())
+				submitTimes.Delete(*b.tx)
 			}
-		}
-	}
-
-	return include, pending
-}
-
-func getTx(address common.Address, hash common.Hash) map[string]interface{} {
-	client := getClient(address)
 
-	result, err := util.GetTransactionByHash(client, hash.ToHex())
-	if err != nil {
-		fmt.Println("failed to get tx ", err, " tx hash ", hash.ToHex())
-		return result
+			lock.Lock()
+			balanceList = append(balanceList, b)
+			fmt.Printf("tx %s confirmed, balance count: %d\n", b.tx.ToHex(), len(balanceList))
+			lock.Unlock()
+		}(b)
 	}
-
-	return result
 }
 
 func send(b *balance) *balance {
@@ -279,54 +376,75 @@ func send(b *balance) *balance {
 	}
 
 	addr, privateKey := crypto.MustGenerateShardKeyPair(b.address.Shard())
+	if mode != 2 {
+		// Mode 2 discards newBalance after sending (it never recycles
+		// recipients back into balanceList), so registering one here would
+		// just leak a pool entry that's never reserved again.
+		wallets.Add(*addr, privateKey, addr.Shard(), 0, big.NewInt(0))
+	}
 	newBalance := &balance{
-		address:    addr,
-		privateKey: privateKey,
-		amount:     amount,
-		shard:      addr.Shard(),
-		nonce:      0,
-		packed:     false,
+		address: addr,
+		amount:  amount,
+		shard:   addr.Shard(),
+		packed:  false,
 	}
 
 	value := big.NewInt(int64(amount))
 	value.Mul(value, common.SeeleToFan)
 
-	client := getRandClient()
-	tx, err := util.GenerateTx(b.privateKey, *addr, value, big.NewInt(1), b.nonce, nil)
+	if faucet != nil {
+		if err := wallets.EnsureFunded(*b.address, faucet, rpcSender{}); err != nil {
+			fmt.Println("faucet refill failed for ", b.address.ToHex(), ": ", err)
+		}
+	}
+
+	nonce, key, err := wallets.Reserve(*b.address)
 	if err != nil {
+		fmt.Println("failed to reserve nonce for ", b.address.ToHex(), ": ", err)
 		return newBalance
 	}
 
+	tx, err := util.GenerateTx(key, *addr, value, big.NewInt(1), nonce, nil)
+	if err != nil {
+		wallets.Release(*b.address, nonce)
+		instrumentation.RPCErrors.WithLabelValues(strconv.Itoa(int(b.shard)), "generateTx").Inc()
+		return newBalance
+	}
+
+	start := time.Now()
+	client := getRandClient()
 	ok, err := util.SendTx(client, tx)
+	clients.Release(client, time.Since(start), err)
 	if !ok || err != nil {
+		wallets.Release(*b.address, nonce)
+		instrumentation.RPCErrors.WithLabelValues(strconv.Itoa(int(b.shard)), "seele_addTx").Inc()
 		return newBalance
 	}
 
-	// update balance by transaction amount and update nonce
-	b.nonce++
+	// update balance by transaction amount and confirm the reserved nonce
+	wallets.Confirm(*b.address, nonce, tx.Hash)
 	b.amount -= amount
 	newBalance.tx = &tx.Hash
+	newBalance.sentFrom = b.address
+
+	shard := strconv.Itoa(int(b.shard))
+	instrumentation.Submitted.WithLabelValues(shard, strconv.Itoa(mode)).Inc()
+	submitTimes.Store(tx.Hash, time.Now())
 
 	return newBalance
 }
 
+// getRandClient picks a client from any shard. The caller must call
+// clients.Release with the outcome once it's done with the client, or the
+// pool's inflight bookkeeping (and the least-inflight strategy) never
+// unwinds.
 func getRandClient() *rpc.Client {
-	if len(clientList) == 0 {
-		panic("no client found")
-	}
-
-	index := rand.Intn(len(clientList))
-
-	count := 0
-	for _, v := range clientList {
-		if count == index {
-			return v
-		}
-
-		count++
+	client, err := clients.Get(rpcpool.Hint{})
+	if err != nil {
+		panic(err)
 	}
 
-	return nil
+	return client
 }
 
 func initAccount(threads int) []*balance {
@@ -389,47 +507,74 @@ func initBalance(balanceList []*balance, keyList []string, start int, end int, w
 		}
 
 		b := &balance{
-			address:    addr,
-			privateKey: key,
-			amount:     amount,
-			shard:      addr.Shard(),
-			packed:     false,
+			address: addr,
+			amount:  amount,
+			shard:   addr.Shard(),
+			packed:  false,
 		}
 
 		fmt.Printf("%s balance is %d\n", b.address.ToHex(), b.amount)
 
 		if b.amount > 0 {
-			b.nonce = getNonce(*b.address)
+			nonce := getNonce(*b.address)
+			cached := big.NewInt(int64(b.amount))
+			cached.Mul(cached, common.SeeleToFan)
+			wallets.Add(*b.address, key, b.shard, nonce, cached)
 			balanceList[i] = b
 		}
 	}
 }
 
 func getBalance(address common.Address) (int, bool) {
+	start := time.Now()
 	client := getClient(address)
 
 	var result api.GetBalanceResponse
-	if err := client.Call(&result, "seele_getBalance", address); err != nil {
+	err := client.Call(&result, "seele_getBalance", address)
+	clients.Release(client, time.Since(start), err)
+	if err != nil {
+		instrumentation.RPCErrors.WithLabelValues(strconv.Itoa(int(address.Shard())), "seele_getBalance").Inc()
 		panic(fmt.Sprintf("failed to get the balance: %s\n", err))
 	}
 
-	return int(result.Balance.Div(result.Balance, common.SeeleToFan).Uint64()), true
+	amount := int(result.Balance.Div(result.Balance, common.SeeleToFan).Uint64())
+	instrumentation.WalletBalance.WithLabelValues(address.ToHex()).Set(float64(amount))
+
+	return amount, true
 }
 
+// getClient picks a client pinned to address's shard. The caller must call
+// clients.Release with the outcome once it's done with the client, or the
+// pool's inflight bookkeeping (and the least-inflight strategy) never
+// unwinds.
 func getClient(address common.Address) *rpc.Client {
-	shard := address.Shard()
-	client := clientList[shard]
-	if client == nil {
-		panic(fmt.Sprintf("not found client in shard %d", shard))
+	client, err := clients.Get(rpcpool.Hint{Shard: address.Shard(), HasShard: true, Address: &address})
+	if err != nil {
+		panic(fmt.Sprintf("not found client in shard %d: %s", address.Shard(), err))
+	}
+
+	return client
+}
+
+// getClientForShard picks a client pinned to shard without needing a
+// specific address on it (e.g. for inclusion checks that only know which
+// shard a tx was sent on). The caller must call clients.Release with the
+// outcome once done.
+func getClientForShard(shard uint) *rpc.Client {
+	client, err := clients.Get(rpcpool.Hint{Shard: shard, HasShard: true})
+	if err != nil {
+		panic(fmt.Sprintf("not found client in shard %d: %s", shard, err))
 	}
 
 	return client
 }
 
 func getNonce(address common.Address) uint64 {
+	start := time.Now()
 	client := getClient(address)
 
 	nonce, err := util.GetAccountNonce(client, address)
+	clients.Release(client, time.Since(start), err)
 	if err != nil {
 		panic(err)
 	}
@@ -437,6 +582,58 @@ func getNonce(address common.Address) uint64 {
 	return nonce
 }
 
+// rpcChainClient adapts the tool's existing getClient/GetAccountNonce RPC
+// calls to walletpool.ChainClient, so wallets.StartReconciler can refresh
+// cached nonces/balances from the node instead of only being exercised in
+// walletpool's own tests.
+type rpcChainClient struct{}
+
+func (rpcChainClient) GetNonce(address common.Address) (uint64, error) {
+	start := time.Now()
+	client := getClient(address)
+	nonce, err := util.GetAccountNonce(client, address)
+	clients.Release(client, time.Since(start), err)
+	return nonce, err
+}
+
+func (rpcChainClient) GetBalance(address common.Address) (*big.Int, error) {
+	start := time.Now()
+	client := getClient(address)
+	var result api.GetBalanceResponse
+	err := client.Call(&result, "seele_getBalance", address)
+	clients.Release(client, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Balance, nil
+}
+
+// rpcSender adapts the tool's existing tx-building/sending calls to
+// walletpool.Sender, so Pool.EnsureFunded can submit a real refill tx
+// instead of only being exercised with a fake in walletpool's own tests.
+type rpcSender struct{}
+
+func (rpcSender) Transfer(from *walletpool.Wallet, nonce uint64, to common.Address, amount *big.Int) (common.Hash, error) {
+	tx, err := util.GenerateTx(from.PrivateKey, to, amount, big.NewInt(1), nonce, nil)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	start := time.Now()
+	client := getClient(from.Address)
+	ok, err := util.SendTx(client, tx)
+	clients.Release(client, time.Since(start), err)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+	if !ok {
+		return common.EmptyHash, fmt.Errorf("seele_addTx rejected the refill tx")
+	}
+
+	return tx.Hash, nil
+}
+
 func getShard(client *rpc.Client) uint {
 	info, err := util.GetInfo(client)
 	if err != nil {
@@ -454,4 +651,11 @@ func init() {
 	sendTxCmd.Flags().BoolVarP(&debug, "debug", "d", false, "whether print more debug info")
 	sendTxCmd.Flags().IntVarP(&mode, "mode", "m", 1, "send tx mode")
 	sendTxCmd.Flags().IntVarP(&threads, "threads", "t", 1, "send tx threads")
+	sendTxCmd.Flags().StringVarP(&scenarioFile, "scenario", "s", "", "scenario file describing a task DAG to run, instead of a hardcoded mode")
+	sendTxCmd.Flags().StringVarP(&rpcStrategy, "rpc-strategy", "", string(rpcpool.RoundRobin), "client selection strategy: round-robin, random, sticky-shard, sticky-address, least-inflight")
+	sendTxCmd.Flags().StringVarP(&metricsAddr, "metrics-addr", "", "", "address to serve Prometheus /metrics on, e.g. :9200 (disabled if empty)")
+	sendTxCmd.Flags().StringVarP(&faucetAddress, "faucet-address", "", "", "keyfile account to refill generated wallets from once their cached balance drops below --faucet-threshold (disabled if empty)")
+	sendTxCmd.Flags().Int64VarP(&faucetThreshold, "faucet-threshold", "", 0, "balance in Fan below which a wallet is refilled from the faucet")
+	sendTxCmd.Flags().Int64VarP(&faucetRefill, "faucet-refill", "", 0, "amount in Fan the faucet sends per refill")
+	sendTxCmd.Flags().DurationVarP(&reconcileInterval, "reconcile-interval", "", 0, "how often to refresh cached nonce/balance from the node in the background (disabled if zero)")
 }
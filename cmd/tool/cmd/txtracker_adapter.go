@@ -0,0 +1,69 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/seeleteam/go-seele/cmd/util"
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/txtracker"
+)
+
+// txTracker is shared by loopCheckMode1 and the scenario driver's
+// await_inclusion task, so both paths get the same mutex-protected waiter
+// map and confirmations handling instead of each rolling their own polling.
+//
+// It's built with NewPolling rather than a push Subscriber because this
+// tree has no node-side block/tx subscription endpoint to consume yet; once
+// one lands in api/light, swap this for txtracker.New(subscriber, ...) and
+// every Await/AwaitN call site is unaffected.
+var txTracker = txtracker.NewPolling(rpcPoller{}, 2*time.Second)
+
+// rpcPoller adapts the existing seele_getTransactionByHash/seele_getInfo
+// calls to txtracker.Poller.
+type rpcPoller struct{}
+
+// IsIncluded checks every shard's endpoint for hash rather than a single
+// random one. A hash only ever lands on the shard its sender belongs to, so
+// picking one node at random (the baseline getTx/getRandClient behavior)
+// means a multi-shard cluster never reports "included" for most hashes and
+// confirmations silently never fire. The shard it was found on is returned
+// alongside, since BlockHeight must be read from that same shard.
+func (rpcPoller) IsIncluded(hash common.Hash) (bool, uint, error) {
+	var lastErr error
+	for shard := range clientList {
+		start := time.Now()
+		client := getClientForShard(shard)
+		result, err := util.GetTransactionByHash(client, hash.ToHex())
+		clients.Release(client, time.Since(start), err)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if result["status"] == "block" {
+			return true, shard, nil
+		}
+	}
+
+	return false, 0, lastErr
+}
+
+// BlockHeight returns shard's own height rather than a random shard's, since
+// shards in a multi-shard cluster advance independently and mixing them
+// would make confirmations fire early, late, or never.
+func (rpcPoller) BlockHeight(shard uint) (uint64, error) {
+	start := time.Now()
+	client := getClientForShard(shard)
+	info, err := util.GetInfo(client)
+	clients.Release(client, time.Since(start), err)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.HeaderHeight, nil
+}
@@ -0,0 +1,103 @@
+package conformance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// passthroughApplier returns pre unchanged, so Run's diffing logic can be
+// exercised without a real state transition.
+type passthroughApplier struct{}
+
+func (passthroughApplier) Apply(pre State, msg Message) (*State, *Receipt, error) {
+	post := pre
+	return &post, &Receipt{}, nil
+}
+
+func TestRunReportsMatchingVector(t *testing.T) {
+	v := &Vector{
+		Version: Version,
+		Name:    "noop",
+		Pre:     State{Accounts: map[string]Account{"a": {Balance: big.NewInt(10)}}},
+		Post:    State{Accounts: map[string]Account{"a": {Balance: big.NewInt(10)}}},
+	}
+
+	results := Run([]*Vector{v}, passthroughApplier{})
+	if len(results) != 1 || !results[0].Passed {
+		t.Fatalf("expected vector to pass, got %+v", results)
+	}
+}
+
+func TestRunReportsBalanceMismatch(t *testing.T) {
+	v := &Vector{
+		Version: Version,
+		Name:    "mismatch",
+		Pre:     State{Accounts: map[string]Account{"a": {Balance: big.NewInt(10)}}},
+		Post:    State{Accounts: map[string]Account{"a": {Balance: big.NewInt(99)}}},
+	}
+
+	results := Run([]*Vector{v}, passthroughApplier{})
+	if len(results) != 1 || results[0].Passed {
+		t.Fatalf("expected vector to fail on balance mismatch, got %+v", results)
+	}
+}
+
+func TestLoadCorpusRoundTripsWriteVector(t *testing.T) {
+	dir := t.TempDir()
+
+	v := &Vector{
+		Name: "roundtrip",
+		Pre:  State{Accounts: map[string]Account{"a": {Balance: big.NewInt(5), Nonce: 1}}},
+		Message: Message{
+			From:   common.Address{1},
+			To:     common.Address{2},
+			Amount: big.NewInt(1),
+		},
+		Post: State{Accounts: map[string]Account{"a": {Balance: big.NewInt(4), Nonce: 2}}},
+	}
+
+	if err := WriteVector(dir, v); err != nil {
+		t.Fatalf("WriteVector: %s", err)
+	}
+
+	loaded, err := LoadCorpus(dir)
+	if err != nil {
+		t.Fatalf("LoadCorpus: %s", err)
+	}
+
+	if len(loaded) != 1 || loaded[0].Name != "roundtrip" {
+		t.Fatalf("expected the written vector back, got %+v", loaded)
+	}
+	if loaded[0].Pre.Accounts["a"].Balance.Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("expected balance to round-trip, got %s", loaded[0].Pre.Accounts["a"].Balance)
+	}
+}
+
+func TestLoadCorpusRejectsWrongVersion(t *testing.T) {
+	dir := t.TempDir()
+
+	// WriteVector always stamps the current Version, so write the file
+	// directly to simulate a vector from a mismatched corpus format.
+	buff, err := json.Marshal(&Vector{Version: "0", Name: "bad"})
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "bad.json"), buff, 0644); err != nil {
+		t.Fatalf("write: %s", err)
+	}
+
+	if _, err := LoadCorpus(dir); err == nil {
+		t.Fatal("expected LoadCorpus to reject a mismatched version")
+	}
+}
+
+func TestGenerateRejectsInvertedRange(t *testing.T) {
+	if _, err := Generate(nil, 10, 5); err == nil {
+		t.Fatal("expected Generate to reject a range end before its start")
+	}
+}
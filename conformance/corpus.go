@@ -0,0 +1,53 @@
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+)
+
+// LoadCorpus reads every *.json file in dir as a Vector. Vectors are
+// returned sorted by file name, so a corpus run is reproducible.
+func LoadCorpus(dir string) ([]*Vector, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	vectors := make([]*Vector, 0, len(matches))
+	for _, path := range matches {
+		buff, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %s", path, err)
+		}
+
+		var v Vector
+		if err := json.Unmarshal(buff, &v); err != nil {
+			return nil, fmt.Errorf("parse %s: %s", path, err)
+		}
+
+		if v.Version != Version {
+			return nil, fmt.Errorf("%s: unsupported corpus version %q, want %q", path, v.Version, Version)
+		}
+
+		vectors = append(vectors, &v)
+	}
+
+	return vectors, nil
+}
+
+// WriteVector writes v to dir/<name>.json, for --generate mode.
+func WriteVector(dir string, v *Vector) error {
+	v.Version = Version
+
+	buff, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, v.Name+".json")
+	return ioutil.WriteFile(path, buff, 0644)
+}
@@ -0,0 +1,55 @@
+package conformance
+
+import "fmt"
+
+// ChainReader is the read side of `--generate`: given a block height, it
+// produces the vector's pre-state, the message that block applied, and the
+// resulting post-state/receipt. Nothing in this repo implements it:
+// cmd/tool's --generate exits with an error instead of walking a chain.
+type ChainReader interface {
+	PreState(height uint64) (State, error)
+	Message(height uint64) (Message, error)
+	PostState(height uint64) (State, error)
+	Receipt(height uint64) (Receipt, error)
+}
+
+// Generate walks [from, to] and produces one Vector per height.
+func Generate(reader ChainReader, from, to uint64) ([]*Vector, error) {
+	if to < from {
+		return nil, fmt.Errorf("conformance: generate range end %d before start %d", to, from)
+	}
+
+	vectors := make([]*Vector, 0, to-from+1)
+	for height := from; height <= to; height++ {
+		pre, err := reader.PreState(height)
+		if err != nil {
+			return vectors, fmt.Errorf("height %d: pre-state: %s", height, err)
+		}
+
+		msg, err := reader.Message(height)
+		if err != nil {
+			return vectors, fmt.Errorf("height %d: message: %s", height, err)
+		}
+
+		post, err := reader.PostState(height)
+		if err != nil {
+			return vectors, fmt.Errorf("height %d: post-state: %s", height, err)
+		}
+
+		receipt, err := reader.Receipt(height)
+		if err != nil {
+			return vectors, fmt.Errorf("height %d: receipt: %s", height, err)
+		}
+
+		vectors = append(vectors, &Vector{
+			Version: Version,
+			Name:    fmt.Sprintf("block-%d", height),
+			Pre:     pre,
+			Message: msg,
+			Post:    post,
+			Receipt: receipt,
+		})
+	}
+
+	return vectors, nil
+}
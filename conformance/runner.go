@@ -0,0 +1,91 @@
+package conformance
+
+import (
+	"fmt"
+)
+
+// Applier applies a message to a pre-state and returns the resulting
+// post-state and receipt. The intent is for cmd/tool to wire this to this
+// repo's core state transition so a fork or light-client reimplementation
+// can supply its own Applier and check it produces the same Post/Receipt for
+// every vector in the corpus; today cmd/tool wires a value-transfer-only
+// scaffold Applier instead, since core has no in-process entry point this
+// tool can call - see cmd/tool/cmd/conformance.go's valueTransferApplier.
+type Applier interface {
+	Apply(pre State, msg Message) (*State, *Receipt, error)
+}
+
+// Result is the outcome of running a single Vector.
+type Result struct {
+	Name   string
+	Passed bool
+	Diffs  []string
+	Err    error
+}
+
+// Run applies every vector via applier and compares the result against its
+// expected Post/Receipt, returning one Result per vector in order.
+func Run(vectors []*Vector, applier Applier) []*Result {
+	results := make([]*Result, 0, len(vectors))
+
+	for _, v := range vectors {
+		post, receipt, err := applier.Apply(v.Pre, v.Message)
+		if err != nil {
+			results = append(results, &Result{Name: v.Name, Err: err})
+			continue
+		}
+
+		diffs := diffState(v.Post, *post)
+		diffs = append(diffs, diffReceipt(v.Receipt, *receipt)...)
+
+		results = append(results, &Result{
+			Name:   v.Name,
+			Passed: len(diffs) == 0,
+			Diffs:  diffs,
+		})
+	}
+
+	return results
+}
+
+func diffState(want, got State) []string {
+	var diffs []string
+
+	for addr, wantAcc := range want.Accounts {
+		gotAcc, ok := got.Accounts[addr]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("account %s: missing from post-state", addr))
+			continue
+		}
+
+		if wantAcc.Nonce != gotAcc.Nonce {
+			diffs = append(diffs, fmt.Sprintf("account %s: nonce want %d got %d", addr, wantAcc.Nonce, gotAcc.Nonce))
+		}
+
+		if (wantAcc.Balance == nil) != (gotAcc.Balance == nil) || (wantAcc.Balance != nil && wantAcc.Balance.Cmp(gotAcc.Balance) != 0) {
+			diffs = append(diffs, fmt.Sprintf("account %s: balance want %s got %s", addr, wantAcc.Balance, gotAcc.Balance))
+		}
+	}
+
+	for addr := range got.Accounts {
+		if _, ok := want.Accounts[addr]; !ok {
+			diffs = append(diffs, fmt.Sprintf("account %s: unexpected account in post-state", addr))
+		}
+	}
+
+	return diffs
+}
+
+func diffReceipt(want, got Receipt) []string {
+	var diffs []string
+
+	if want.GasUsed != got.GasUsed {
+		diffs = append(diffs, fmt.Sprintf("receipt: gasUsed want %d got %d", want.GasUsed, got.GasUsed))
+	}
+
+	if want.Failed != got.Failed {
+		diffs = append(diffs, fmt.Sprintf("receipt: failed want %v got %v", want.Failed, got.Failed))
+	}
+
+	return diffs
+}
@@ -0,0 +1,66 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package conformance defines a versioned corpus of test vectors describing
+// a pre-state, a message to apply, and the expected post-state/receipt, so
+// forks and light-client implementations of Seele can check they agree with
+// this one on state transitions without standing up a full node.
+//
+// The corpus format is deliberately decoupled from core's concrete types via
+// the Applier interface: a vector only needs accounts, balances, nonces and
+// code, so any implementation - this repo's core package, or a third-party
+// reimplementation - can plug in and be checked the same way.
+package conformance
+
+import (
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Version is the corpus format version this package reads/writes. Bump it
+// whenever Vector's fields change in an incompatible way.
+const Version = "1"
+
+// Account is one account's state, as tracked by a Vector's pre/post-state.
+type Account struct {
+	Balance *big.Int `json:"balance"`
+	Nonce   uint64   `json:"nonce"`
+	Code    []byte   `json:"code,omitempty"`
+}
+
+// State is a snapshot of every account a vector cares about, keyed by the
+// account's hex address (common.Address isn't a valid JSON map key).
+type State struct {
+	Accounts map[string]Account `json:"accounts"`
+}
+
+// Message is the transaction (or block, for a `--generate`d vector covering
+// a full block) being applied on top of Pre.
+type Message struct {
+	From     common.Address `json:"from"`
+	To       common.Address `json:"to"`
+	Amount   *big.Int       `json:"amount"`
+	GasPrice *big.Int       `json:"gasPrice"`
+	Nonce    uint64         `json:"nonce"`
+	Payload  []byte         `json:"payload,omitempty"`
+}
+
+// Receipt is the expected outcome of applying a Message.
+type Receipt struct {
+	GasUsed uint64 `json:"gasUsed"`
+	Failed  bool   `json:"failed"`
+}
+
+// Vector is one self-contained test case: apply Message to Pre and expect
+// to land on Post, producing Receipt.
+type Vector struct {
+	Version string  `json:"version"`
+	Name    string  `json:"name"`
+	Pre     State   `json:"pre"`
+	Message Message `json:"message"`
+	Post    State   `json:"post"`
+	Receipt Receipt `json:"receipt"`
+}
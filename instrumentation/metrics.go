@@ -0,0 +1,82 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package instrumentation defines the Prometheus metrics the sendtx load
+// test driver (and anything else that shares its rpcpool/walletpool/
+// txtracker plumbing) reports, and a small helper to serve them over HTTP.
+// Metrics are registered against a package-level Registry rather than the
+// global prometheus.DefaultRegisterer, so a node-embedded metrics endpoint
+// can pull the exact same registry via LightBackend.Metrics() instead of
+// standing up a second one.
+package instrumentation
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry collects every metric this package defines. Embedders that
+// expose their own /metrics endpoint should serve this registry rather
+// than registering their own copies of the same metrics.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	// Submitted counts every tx the node accepted on submission, labeled by
+	// the sending wallet's shard and the sendtx mode that generated it.
+	// Failed submissions are not counted here; RPCErrors counts them.
+	Submitted = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "seele_sendtx_submitted_total",
+		Help: "Total number of transactions submitted by the sendtx driver.",
+	}, []string{"shard", "mode"})
+
+	// Confirmed counts txs that reached the configured confirmation depth.
+	Confirmed = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "seele_sendtx_confirmed_total",
+		Help: "Total number of transactions confirmed by the sendtx driver.",
+	}, []string{"shard"})
+
+	// InclusionSeconds observes the time between submitting a tx and it
+	// being confirmed, so p50/p99 inclusion latency can be graphed.
+	InclusionSeconds = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "seele_sendtx_inclusion_seconds",
+		Help:    "Time between a transaction's submission and its confirmation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"shard"})
+
+	// RPCErrors counts failed send-path operations, labeled by the shard the
+	// operation was for and the operation that failed (an RPC method name
+	// such as "seele_addTx", or a local step like "generateTx" that never
+	// reaches the network).
+	RPCErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "seele_sendtx_rpc_errors_total",
+		Help: "Total number of RPC errors observed by the sendtx driver.",
+	}, []string{"shard", "method"})
+
+	// WalletBalance tracks the last balance observed for an address, in
+	// Seele units, as of the last getBalance call.
+	WalletBalance = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "seele_sendtx_wallet_balance",
+		Help: "Last observed balance of a sendtx wallet, in Seele.",
+	}, []string{"address"})
+)
+
+// StartServer serves Registry as /metrics on addr in the background. It
+// does not block; the caller is expected to keep running for as long as the
+// metrics should stay available.
+func StartServer(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			panic("instrumentation: metrics server failed: " + err.Error())
+		}
+	}()
+}
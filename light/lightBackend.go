@@ -1,8 +1,10 @@
 package light
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/seeleteam/go-seele/api"
 	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/instrumentation"
 	"github.com/seeleteam/go-seele/log"
 	"github.com/seeleteam/go-seele/p2p"
 )
@@ -12,9 +14,15 @@ type LightBackend struct {
 }
 
 func NewLightBackend(s *ServiceClient) *LightBackend {
-	return &LightBackend{s}
+	return &LightBackend{s: s}
 }
 
+// Metrics returns the instrumentation registry backing the sendtx
+// submitted/confirmed/inclusion/rpc-error/balance metrics, so a
+// node-embedded metrics endpoint can serve the same data instead of
+// running a second, disjoint registry.
+func (l *LightBackend) Metrics() *prometheus.Registry { return instrumentation.Registry }
+
 func (l *LightBackend) TxPoolBackend() api.Pool { return l.s.txPool }
 
 func (l *LightBackend) GetNetVersion() uint64 { return l.s.networkID }
@@ -0,0 +1,54 @@
+package rpcpool
+
+import (
+	"time"
+
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// maxRetries bounds how many distinct endpoints Call will try before giving
+// up, so a cluster-wide outage fails fast instead of looping forever.
+const maxRetries = 3
+
+// IsTransient classifies an error returned by fn as worth retrying on a
+// different endpoint. Callers that already know an error is transient (e.g.
+// a network timeout) can pass their own classifier via CallWithRetry.
+func IsTransient(err error) bool {
+	return err != nil
+}
+
+// Call gets a client for hint, invokes fn, and reports the outcome back to
+// the pool. On a transient error it asks Get for a client again, making at
+// most maxRetries attempts in all. Get does not exclude endpoints that
+// already failed, so whether a retry lands on another endpoint depends on
+// the strategy: the sticky strategies hand out the same one again.
+func (p *Pool) Call(hint Hint, fn func(*rpc.Client) error) error {
+	return p.CallWithRetry(hint, fn, IsTransient)
+}
+
+// CallWithRetry is Call with a caller-supplied retryable classifier.
+func (p *Pool) CallWithRetry(hint Hint, fn func(*rpc.Client) error, retryable func(error) bool) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		client, err := p.Get(hint)
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		err = fn(client)
+		p.Release(client, time.Since(start), err)
+
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+		if !retryable(err) {
+			return err
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,52 @@
+package rpcpool
+
+import (
+	"context"
+	"time"
+
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// maxConsecutiveFailures is how many failed probes in a row mark an
+// endpoint unhealthy, so a single dropped packet doesn't pull a node out of
+// rotation.
+const maxConsecutiveFailures = 3
+
+// StartHealthProbe periodically calls probe (typically a seele_getInfo
+// request) against every registered endpoint, removing endpoints that fail
+// repeatedly and re-adding them once probe succeeds again.
+func (p *Pool) StartHealthProbe(ctx context.Context, interval time.Duration, probe func(*rpc.Client) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		failures := make(map[*endpoint]int)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				endpoints := append([]*endpoint(nil), p.all...)
+				p.mu.Unlock()
+
+				for _, e := range endpoints {
+					err := probe(e.client)
+
+					p.mu.Lock()
+					if err != nil {
+						failures[e]++
+						if failures[e] >= maxConsecutiveFailures {
+							e.healthy = false
+						}
+					} else {
+						failures[e] = 0
+						e.healthy = true
+					}
+					p.mu.Unlock()
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,168 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package rpcpool picks an *rpc.Client to send a request to out of a set of
+// node endpoints, replacing the sendtx tool's getRandClient map iteration
+// with something that can health-check and pin requests.
+package rpcpool
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+// Strategy selects which endpoint a Pool hands out for a given request.
+type Strategy string
+
+// Supported selection strategies.
+const (
+	RoundRobin      Strategy = "round-robin"
+	Random          Strategy = "random"
+	StickyByShard   Strategy = "sticky-shard"
+	StickyByAddress Strategy = "sticky-address"
+	LeastInflight   Strategy = "least-inflight"
+)
+
+// Hint narrows down which endpoint Get should prefer. Shard/Address are
+// optional. When HasShard is set, every strategy picks only among that
+// shard's endpoints; Address is used only by StickyByAddress.
+type Hint struct {
+	Shard    uint
+	HasShard bool
+	Address  *common.Address
+}
+
+// endpoint tracks one RPC client plus its bookkeeping: the health flag set
+// by the background health probe and checked by Get, the inflight count
+// LeastInflight picks by, and a rolling average latency that Release
+// records (no strategy reads it).
+type endpoint struct {
+	shard      uint
+	client     *rpc.Client
+	healthy    bool
+	inflight   int64
+	avgLatency time.Duration
+}
+
+// Pool is a set of node endpoints with a selectable scheduling strategy.
+type Pool struct {
+	mu       sync.Mutex
+	strategy Strategy
+	byShard  map[uint][]*endpoint
+	all      []*endpoint
+	next     int // round-robin cursor
+}
+
+// New builds a Pool from a shard->client map, the same shape sendtx's
+// clientList already has.
+func New(clients map[uint]*rpc.Client, strategy Strategy) *Pool {
+	p := &Pool{
+		strategy: strategy,
+		byShard:  make(map[uint][]*endpoint),
+	}
+
+	for shard, client := range clients {
+		p.AddEndpoint(shard, client)
+	}
+
+	return p
+}
+
+// AddEndpoint registers another client for shard. Multiple endpoints per
+// shard are supported so a real multi-node cluster can be load-balanced
+// across, not just pinned one-client-per-shard.
+func (p *Pool) AddEndpoint(shard uint, client *rpc.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := &endpoint{shard: shard, client: client, healthy: true}
+	p.byShard[shard] = append(p.byShard[shard], e)
+	p.all = append(p.all, e)
+}
+
+// Get returns a client matching hint under the Pool's strategy. The caller
+// must call Release with the observed latency/error once done, so the pool
+// can keep its inflight counts current.
+func (p *Pool) Get(hint Hint) (*rpc.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	candidates := p.all
+	if hint.HasShard {
+		candidates = p.byShard[hint.Shard]
+	}
+
+	healthy := make([]*endpoint, 0, len(candidates))
+	for _, e := range candidates {
+		if e.healthy {
+			healthy = append(healthy, e)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("rpcpool: no healthy endpoint available")
+	}
+
+	var chosen *endpoint
+	switch p.strategy {
+	case Random:
+		chosen = healthy[rand.Intn(len(healthy))]
+	case StickyByAddress:
+		if hint.Address == nil {
+			chosen = healthy[rand.Intn(len(healthy))]
+			break
+		}
+		h := fnv.New32a()
+		h.Write(hint.Address.Bytes())
+		chosen = healthy[int(h.Sum32())%len(healthy)]
+	case LeastInflight:
+		chosen = healthy[0]
+		for _, e := range healthy[1:] {
+			if e.inflight < chosen.inflight {
+				chosen = e
+			}
+		}
+	case StickyByShard:
+		h := fnv.New32a()
+		h.Write([]byte{byte(hint.Shard), byte(hint.Shard >> 8), byte(hint.Shard >> 16), byte(hint.Shard >> 24)})
+		chosen = healthy[int(h.Sum32())%len(healthy)]
+	default: // RoundRobin
+		chosen = healthy[p.next%len(healthy)]
+		p.next++
+	}
+
+	chosen.inflight++
+	return chosen.client, nil
+}
+
+// Release reports the outcome of a request obtained via Get, so the pool
+// can update inflight counts and the rolling latency average. err does not
+// change an endpoint's health; only the health probe does that.
+func (p *Pool) Release(client *rpc.Client, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.all {
+		if e.client == client {
+			if e.inflight > 0 {
+				e.inflight--
+			}
+
+			if e.avgLatency == 0 {
+				e.avgLatency = latency
+			} else {
+				e.avgLatency = (e.avgLatency + latency) / 2
+			}
+
+			return
+		}
+	}
+}
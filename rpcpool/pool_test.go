@@ -0,0 +1,130 @@
+package rpcpool
+
+import (
+	"testing"
+
+	"github.com/seeleteam/go-seele/rpc"
+)
+
+func TestPoolRoundRobinCyclesEndpoints(t *testing.T) {
+	a, b := new(rpc.Client), new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, RoundRobin)
+	p.AddEndpoint(0, b)
+
+	seen := make(map[*rpc.Client]int)
+	for i := 0; i < 4; i++ {
+		client, err := p.Get(Hint{})
+		if err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+		seen[client]++
+	}
+
+	if seen[a] != 2 || seen[b] != 2 {
+		t.Fatalf("expected an even round-robin split, got %v", seen)
+	}
+}
+
+func TestPoolLeastInflightPrefersIdleEndpoint(t *testing.T) {
+	a, b := new(rpc.Client), new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, LeastInflight)
+	p.AddEndpoint(0, b)
+
+	// Load up `a` with in-flight requests that are never released, which is
+	// the bug the pool must not reproduce: Get must still account for them
+	// rather than always treating every endpoint as equally idle.
+	for i := 0; i < 3; i++ {
+		if _, err := p.Get(Hint{}); err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+	}
+
+	// Whichever endpoint has accumulated inflight should now lose out to the
+	// other every subsequent call, once its own count grows too.
+	client, err := p.Get(Hint{})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	p.Release(client, 0, nil)
+
+	client2, err := p.Get(Hint{})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+
+	if client != client2 {
+		t.Fatalf("expected least-inflight to favor the endpoint just released")
+	}
+}
+
+func TestPoolReleaseUnwindsInflight(t *testing.T) {
+	a := new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, LeastInflight)
+
+	client, err := p.Get(Hint{})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+
+	if p.all[0].inflight != 1 {
+		t.Fatalf("expected inflight 1 after Get, got %d", p.all[0].inflight)
+	}
+
+	p.Release(client, 0, nil)
+
+	if p.all[0].inflight != 0 {
+		t.Fatalf("expected inflight 0 after Release, got %d", p.all[0].inflight)
+	}
+}
+
+func TestPoolGetSkipsUnhealthyEndpoints(t *testing.T) {
+	a, b := new(rpc.Client), new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, RoundRobin)
+	p.AddEndpoint(0, b)
+
+	p.all[0].healthy = false
+
+	for i := 0; i < 3; i++ {
+		client, err := p.Get(Hint{})
+		if err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+		if client != b {
+			t.Fatalf("expected only the healthy endpoint to be returned")
+		}
+	}
+}
+
+func TestPoolStickyByShardPinsToOneEndpointPerShard(t *testing.T) {
+	a, b := new(rpc.Client), new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, StickyByShard)
+	p.AddEndpoint(0, b)
+
+	// Requests for shard 0 must always land on the same one of its two
+	// endpoints - not rotate between them, which is plain round-robin and
+	// defeats the point of "sticky".
+	first, err := p.Get(Hint{Shard: 0, HasShard: true})
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		client, err := p.Get(Hint{Shard: 0, HasShard: true})
+		if err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+		if client != first {
+			t.Fatalf("expected StickyByShard to keep returning the same endpoint for shard 0, got a different one on call %d", i)
+		}
+	}
+}
+
+func TestPoolGetNoHealthyEndpoints(t *testing.T) {
+	a := new(rpc.Client)
+	p := New(map[uint]*rpc.Client{0: a}, RoundRobin)
+	p.all[0].healthy = false
+
+	if _, err := p.Get(Hint{}); err == nil {
+		t.Fatal("expected an error when no endpoint is healthy")
+	}
+}
@@ -0,0 +1,141 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventStatus describes the lifecycle point an Event was emitted for.
+type EventStatus string
+
+// Possible EventStatus values, in the order a single task emits them.
+const (
+	EventStarted  EventStatus = "started"
+	EventFinished EventStatus = "finished"
+	EventFailed   EventStatus = "failed"
+)
+
+// Event is one line of structured progress, written as JSON to the runner's
+// output so scenario runs can be consumed by CI without scraping fmt.Printf
+// output.
+type Event struct {
+	Task   string      `json:"task"`
+	Status EventStatus `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Time   time.Time   `json:"time"`
+}
+
+// Runner executes a Config as a DAG of tasks: a task starts as soon as every
+// task in its DependsOn list has finished, so independent branches run
+// concurrently instead of one `mode N` loop at a time.
+type Runner struct {
+	config *Config
+	driver Driver
+	out    io.Writer
+}
+
+// NewRunner builds a Runner for the given scenario, reporting progress as
+// newline-delimited JSON to out. A nil out defaults to os.Stdout.
+func NewRunner(config *Config, driver Driver, out io.Writer) *Runner {
+	if out == nil {
+		out = os.Stdout
+	}
+
+	return &Runner{config: config, driver: driver, out: out}
+}
+
+// Run executes every task in the scenario, returning the first error
+// encountered. Callers (e.g. the sendtx command) should exit non-zero when
+// Run returns a non-nil error, since that signals an assertion failure or a
+// task that could not complete.
+func (r *Runner) Run(ctx context.Context) error {
+	byName := make(map[string]TaskConfig, len(r.config.Tasks))
+	for _, t := range r.config.Tasks {
+		byName[t.Name] = t
+	}
+
+	done := make(map[string]chan struct{}, len(r.config.Tasks))
+	for _, t := range r.config.Tasks {
+		done[t.Name] = make(chan struct{})
+	}
+
+	sc := &Context{
+		Context: ctx,
+		Driver:  r.driver,
+		Report:  r.emit,
+		results: make(map[string]interface{}),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	for _, cfg := range r.config.Tasks {
+		cfg := cfg
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[cfg.Name])
+
+			for _, dep := range cfg.DependsOn {
+				select {
+				case <-done[dep]:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if err := r.runOne(sc, cfg); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("task %s: %w", cfg.Name, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
+
+func (r *Runner) runOne(sc *Context, cfg TaskConfig) error {
+	task, err := NewTask(cfg)
+	if err != nil {
+		return err
+	}
+
+	r.emit(Event{Task: cfg.Name, Status: EventStarted, Time: time.Now()})
+
+	defer task.Cleanup(sc)
+
+	if err := task.Prepare(sc); err != nil {
+		r.emit(Event{Task: cfg.Name, Status: EventFailed, Error: err.Error(), Time: time.Now()})
+		return err
+	}
+
+	if err := task.Run(sc); err != nil {
+		r.emit(Event{Task: cfg.Name, Status: EventFailed, Error: err.Error(), Time: time.Now()})
+		return err
+	}
+
+	r.emit(Event{Task: cfg.Name, Status: EventFinished, Time: time.Now()})
+	return nil
+}
+
+func (r *Runner) emit(e Event) {
+	buff, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintln(r.out, string(buff))
+}
@@ -0,0 +1,107 @@
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// recordingDriver is a Driver fake that records the order its methods are
+// invoked in, so tests can assert on DAG scheduling without a real node.
+type recordingDriver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (d *recordingDriver) record(event string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, event)
+}
+
+func (d *recordingDriver) GenerateWallets(n int, shard uint) ([]*Wallet, error) {
+	time.Sleep(10 * time.Millisecond)
+	d.record("generate:" + string(rune('0'+shard)))
+	wallets := make([]*Wallet, n)
+	for i := range wallets {
+		wallets[i] = &Wallet{Address: new(common.Address), Shard: shard}
+	}
+	return wallets, nil
+}
+
+func (d *recordingDriver) FundWallets(wallets []*Wallet, amount *big.Int) error {
+	d.record("fund")
+	return nil
+}
+
+func (d *recordingDriver) SendTransfers(from, to []*Wallet, amount *big.Int) ([]common.Hash, error) {
+	d.record("send")
+	return []common.Hash{{}}, nil
+}
+
+func (d *recordingDriver) SendContractCalls(from []*Wallet, to common.Address, payload []byte) ([]common.Hash, error) {
+	d.record("call")
+	return []common.Hash{{}}, nil
+}
+
+func (d *recordingDriver) AwaitInclusion(ctx context.Context, hashes []common.Hash, timeout time.Duration) error {
+	d.record("await")
+	return nil
+}
+
+func (d *recordingDriver) Balance(address common.Address) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+func TestRunnerRunsDependentTaskAfterItsDependency(t *testing.T) {
+	driver := &recordingDriver{}
+	config := &Config{
+		Name: "order",
+		Tasks: []TaskConfig{
+			{Name: "gen", Type: TaskGenerateWallets, Params: json.RawMessage(`{"count":1,"shard":0}`)},
+			{Name: "send", Type: TaskSendTransfers, DependsOn: []string{"gen"}, Params: json.RawMessage(`{"from":"gen","to":"gen","amount":1}`)},
+		},
+	}
+
+	runner := NewRunner(config, driver, ioutil.Discard)
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if len(driver.events) != 2 || driver.events[0] != "generate:0" || driver.events[1] != "send" {
+		t.Fatalf("expected [generate:0 send], got %v", driver.events)
+	}
+}
+
+// TestRunnerConcurrentIndependentTasks exercises the scenario the review
+// flagged: two tasks with no DependsOn relationship both call
+// Context.SetResult from their own goroutine. It must not race.
+func TestRunnerConcurrentIndependentTasks(t *testing.T) {
+	driver := &recordingDriver{}
+	config := &Config{
+		Name: "concurrent",
+		Tasks: []TaskConfig{
+			{Name: "a", Type: TaskGenerateWallets, Params: json.RawMessage(`{"count":1,"shard":0}`)},
+			{Name: "b", Type: TaskGenerateWallets, Params: json.RawMessage(`{"count":1,"shard":1}`)},
+		},
+	}
+
+	runner := NewRunner(config, driver, ioutil.Discard)
+	if err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+
+	driver.mu.Lock()
+	defer driver.mu.Unlock()
+	if len(driver.events) != 2 {
+		t.Fatalf("expected both independent tasks to complete, got %v", driver.events)
+	}
+}
@@ -0,0 +1,58 @@
+package scenario
+
+import "testing"
+
+func TestConfigValidateAcceptsDAG(t *testing.T) {
+	c := &Config{
+		Name: "ok",
+		Tasks: []TaskConfig{
+			{Name: "a"},
+			{Name: "b", DependsOn: []string{"a"}},
+			{Name: "c", DependsOn: []string{"a", "b"}},
+		},
+	}
+
+	if err := c.validate(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestConfigValidateDetectsCycle(t *testing.T) {
+	c := &Config{
+		Name: "cyclic",
+		Tasks: []TaskConfig{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		},
+	}
+
+	if err := c.validate(); err == nil {
+		t.Fatal("expected dependency cycle to be rejected")
+	}
+}
+
+func TestConfigValidateDetectsSelfDependency(t *testing.T) {
+	c := &Config{
+		Name:  "self",
+		Tasks: []TaskConfig{{Name: "a", DependsOn: []string{"a"}}},
+	}
+
+	if err := c.validate(); err == nil {
+		t.Fatal("expected self-dependency to be rejected as a cycle")
+	}
+}
+
+func TestConfigValidateDetectsLongerCycle(t *testing.T) {
+	c := &Config{
+		Name: "cyclic",
+		Tasks: []TaskConfig{
+			{Name: "a", DependsOn: []string{"c"}},
+			{Name: "b", DependsOn: []string{"a"}},
+			{Name: "c", DependsOn: []string{"b"}},
+		},
+	}
+
+	if err := c.validate(); err == nil {
+		t.Fatal("expected a 3-task cycle to be rejected")
+	}
+}
@@ -0,0 +1,72 @@
+package scenario
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Task is a single unit of work in a scenario DAG. Prepare runs once all of
+// a task's dependencies have completed, Run does the actual work, and
+// Cleanup always runs afterwards, even if Run failed.
+type Task interface {
+	Prepare(ctx *Context) error
+	Run(ctx *Context) error
+	Cleanup(ctx *Context) error
+}
+
+// Wallet is the subset of account state a scenario task needs to drive load
+// against the chain.
+type Wallet struct {
+	Address *common.Address
+	Shard   uint
+	Nonce   uint64
+}
+
+// Driver is the chain-facing side of a scenario run. The runner is
+// transport-agnostic: it only knows how to sequence tasks, while Driver
+// knows how to actually talk to the node(s) under test.
+type Driver interface {
+	GenerateWallets(n int, shard uint) ([]*Wallet, error)
+	FundWallets(wallets []*Wallet, amount *big.Int) error
+	SendTransfers(from []*Wallet, to []*Wallet, amount *big.Int) ([]common.Hash, error)
+	SendContractCalls(from []*Wallet, to common.Address, payload []byte) ([]common.Hash, error)
+	AwaitInclusion(ctx context.Context, hashes []common.Hash, timeout time.Duration) error
+	Balance(address common.Address) (*big.Int, error)
+}
+
+// Context is threaded through a task's lifecycle. It carries the shared
+// Driver, cancellation, and the named results of already-completed tasks so
+// later tasks (e.g. send_transfers depending on generate_wallets) can look
+// up what their dependencies produced.
+type Context struct {
+	context.Context
+
+	Driver Driver
+	Report func(Event)
+
+	resultsMu sync.Mutex
+	results   map[string]interface{}
+}
+
+// Result returns the output a named task stored via SetResult, if any.
+func (c *Context) Result(name string) (interface{}, bool) {
+	c.resultsMu.Lock()
+	defer c.resultsMu.Unlock()
+
+	v, ok := c.results[name]
+	return v, ok
+}
+
+// SetResult records this task's output for downstream tasks to consume.
+// Tasks whose dependencies are satisfied at the same time run concurrently
+// (see Runner.Run), so writes must be synchronized.
+func (c *Context) SetResult(name string, value interface{}) {
+	c.resultsMu.Lock()
+	defer c.resultsMu.Unlock()
+
+	c.results[name] = value
+}
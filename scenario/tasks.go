@@ -0,0 +1,348 @@
+package scenario
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// NewTask builds the concrete Task for a TaskConfig, decoding its Params
+// into the task's typed parameter struct.
+func NewTask(cfg TaskConfig) (Task, error) {
+	switch cfg.Type {
+	case TaskGenerateWallets:
+		t := &generateWalletsTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskFundWallets:
+		t := &fundWalletsTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskSendTransfers:
+		t := &sendTransfersTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskSendContractCall:
+		t := &sendContractCallsTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskAwaitInclusion:
+		t := &awaitInclusionTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskAssertBalance:
+		t := &assertBalanceTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskSleep:
+		t := &sleepTask{name: cfg.Name}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskRunParallel:
+		t := &runGroupTask{name: cfg.Name, parallel: true}
+		return t, unmarshalParams(cfg, &t.params)
+	case TaskRunSequential:
+		t := &runGroupTask{name: cfg.Name, parallel: false}
+		return t, unmarshalParams(cfg, &t.params)
+	default:
+		return nil, fmt.Errorf("unknown task type %q", cfg.Type)
+	}
+}
+
+func unmarshalParams(cfg TaskConfig, out interface{}) error {
+	if len(cfg.Params) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(cfg.Params, out); err != nil {
+		return fmt.Errorf("task %s: invalid params: %s", cfg.Name, err)
+	}
+
+	return nil
+}
+
+// noopLifecycle gives tasks that don't need Prepare/Cleanup a default.
+type noopLifecycle struct{}
+
+func (noopLifecycle) Prepare(ctx *Context) error { return nil }
+func (noopLifecycle) Cleanup(ctx *Context) error { return nil }
+
+type generateWalletsParams struct {
+	Count int  `json:"count"`
+	Shard uint `json:"shard"`
+}
+
+type generateWalletsTask struct {
+	noopLifecycle
+	name   string
+	params generateWalletsParams
+}
+
+func (t *generateWalletsTask) Run(ctx *Context) error {
+	wallets, err := ctx.Driver.GenerateWallets(t.params.Count, t.params.Shard)
+	if err != nil {
+		return err
+	}
+
+	ctx.SetResult(t.name, wallets)
+	return nil
+}
+
+type fundWalletsParams struct {
+	Wallets string `json:"wallets"` // name of the generate_wallets task to fund
+	Amount  int64  `json:"amount"`
+}
+
+type fundWalletsTask struct {
+	noopLifecycle
+	name   string
+	params fundWalletsParams
+}
+
+func (t *fundWalletsTask) Run(ctx *Context) error {
+	wallets, err := walletsResult(ctx, t.params.Wallets)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Driver.FundWallets(wallets, big.NewInt(t.params.Amount))
+}
+
+type sendTransfersParams struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int64  `json:"amount"`
+}
+
+type sendTransfersTask struct {
+	noopLifecycle
+	name   string
+	params sendTransfersParams
+}
+
+func (t *sendTransfersTask) Run(ctx *Context) error {
+	from, err := walletsResult(ctx, t.params.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := walletsResult(ctx, t.params.To)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := ctx.Driver.SendTransfers(from, to, big.NewInt(t.params.Amount))
+	if err != nil {
+		return err
+	}
+
+	ctx.SetResult(t.name, hashes)
+	return nil
+}
+
+type sendContractCallsParams struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Payload string `json:"payload"` // hex-encoded call data
+}
+
+type sendContractCallsTask struct {
+	noopLifecycle
+	name   string
+	params sendContractCallsParams
+}
+
+func (t *sendContractCallsTask) Run(ctx *Context) error {
+	from, err := walletsResult(ctx, t.params.From)
+	if err != nil {
+		return err
+	}
+
+	payload, err := hex.DecodeString(strings.TrimPrefix(t.params.Payload, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid payload: %s", err)
+	}
+
+	to, err := common.HexToAddress(t.params.To)
+	if err != nil {
+		return fmt.Errorf("invalid to address: %s", err)
+	}
+
+	hashes, err := ctx.Driver.SendContractCalls(from, to, payload)
+	if err != nil {
+		return err
+	}
+
+	ctx.SetResult(t.name, hashes)
+	return nil
+}
+
+type awaitInclusionParams struct {
+	Hashes  string `json:"hashes"` // name of the task whose result is []common.Hash
+	Timeout string `json:"timeout"`
+}
+
+type awaitInclusionTask struct {
+	noopLifecycle
+	name   string
+	params awaitInclusionParams
+}
+
+func (t *awaitInclusionTask) Run(ctx *Context) error {
+	v, ok := ctx.Result(t.params.Hashes)
+	if !ok {
+		return fmt.Errorf("no such task result %q", t.params.Hashes)
+	}
+
+	hashes, ok := v.([]common.Hash)
+	if !ok {
+		return fmt.Errorf("task result %q is not a list of tx hashes", t.params.Hashes)
+	}
+
+	timeout := 2 * time.Minute
+	if t.params.Timeout != "" {
+		d, err := time.ParseDuration(t.params.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout: %s", err)
+		}
+		timeout = d
+	}
+
+	return ctx.Driver.AwaitInclusion(ctx.Context, hashes, timeout)
+}
+
+type assertBalanceParams struct {
+	Address string `json:"address"`
+	Equals  int64  `json:"equals"`
+}
+
+type assertBalanceTask struct {
+	noopLifecycle
+	name   string
+	params assertBalanceParams
+}
+
+func (t *assertBalanceTask) Run(ctx *Context) error {
+	address, err := common.HexToAddress(t.params.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %s", err)
+	}
+
+	balance, err := ctx.Driver.Balance(address)
+	if err != nil {
+		return err
+	}
+
+	if balance.Cmp(big.NewInt(t.params.Equals)) != 0 {
+		return fmt.Errorf("assertion failed: balance of %s is %s, want %d", t.params.Address, balance, t.params.Equals)
+	}
+
+	return nil
+}
+
+type sleepParams struct {
+	Duration string `json:"duration"`
+}
+
+type sleepTask struct {
+	noopLifecycle
+	name   string
+	params sleepParams
+}
+
+func (t *sleepTask) Run(ctx *Context) error {
+	d, err := time.ParseDuration(t.params.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %s", err)
+	}
+
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func walletsResult(ctx *Context, name string) ([]*Wallet, error) {
+	v, ok := ctx.Result(name)
+	if !ok {
+		return nil, fmt.Errorf("no such task result %q", name)
+	}
+
+	wallets, ok := v.([]*Wallet)
+	if !ok {
+		return nil, fmt.Errorf("task result %q is not a wallet list", name)
+	}
+
+	return wallets, nil
+}
+
+type runGroupParams struct {
+	Tasks []TaskConfig `json:"tasks"`
+}
+
+// runGroupTask runs a nested list of tasks, either all at once
+// (run_parallel) or one after another (run_sequential). Unlike the outer
+// scenario DAG, a group's own DependsOn fields are ignored: grouping is the
+// scheduling primitive here, not dependency order.
+type runGroupTask struct {
+	noopLifecycle
+	name     string
+	parallel bool
+	params   runGroupParams
+}
+
+func (t *runGroupTask) Run(ctx *Context) error {
+	if t.parallel {
+		return t.runParallel(ctx)
+	}
+
+	for _, cfg := range t.params.Tasks {
+		if err := runNested(ctx, cfg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *runGroupTask) runParallel(ctx *Context) error {
+	errCh := make(chan error, len(t.params.Tasks))
+	for _, cfg := range t.params.Tasks {
+		cfg := cfg
+		go func() {
+			errCh <- runNested(ctx, cfg)
+		}()
+	}
+
+	var firstErr error
+	for range t.params.Tasks {
+		if err := <-errCh; err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func runNested(ctx *Context, cfg TaskConfig) error {
+	task, err := NewTask(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx.Report(Event{Task: cfg.Name, Status: EventStarted, Time: time.Now()})
+	defer task.Cleanup(ctx)
+
+	if err := task.Prepare(ctx); err != nil {
+		ctx.Report(Event{Task: cfg.Name, Status: EventFailed, Error: err.Error(), Time: time.Now()})
+		return err
+	}
+
+	if err := task.Run(ctx); err != nil {
+		ctx.Report(Event{Task: cfg.Name, Status: EventFailed, Error: err.Error(), Time: time.Now()})
+		return err
+	}
+
+	ctx.Report(Event{Task: cfg.Name, Status: EventFinished, Time: time.Now()})
+	return nil
+}
@@ -0,0 +1,32 @@
+package txtracker
+
+import (
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Poller is the polling fallback used for hashes a Subscriber hasn't
+// reported within FallbackAfter - or the only signal source when no
+// Subscriber is wired up at all (NewPolling).
+type Poller interface {
+	// IsIncluded reports whether hash has landed in a block yet, and which
+	// shard it was found on.
+	IsIncluded(hash common.Hash) (included bool, shard uint, err error)
+
+	// BlockHeight returns shard's current chain height, used to count
+	// confirmations once a hash is known to be included on that shard. A
+	// multi-shard cluster's shards advance independently, so this must be
+	// the height of the same shard IsIncluded found the hash on, not an
+	// arbitrary one.
+	BlockHeight(shard uint) (uint64, error)
+}
+
+// NewPolling builds a Tracker with no Subscriber, so every Await/AwaitN call
+// is served by poller from the start (FallbackAfter effectively zero). This
+// is the shape to use until a node exposes a push subscription endpoint;
+// callers can switch to New with a real Subscriber later without changing
+// call sites.
+func NewPolling(poller Poller, pollInterval time.Duration) *Tracker {
+	return New(nil, poller, 0, pollInterval)
+}
@@ -0,0 +1,304 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package txtracker watches pending transactions and signals when they're
+// included (and, optionally, confirmed by further blocks), replacing the
+// fixed 30s ticker on which the sendtx tool's loopCheckMode1 re-polled every
+// pending hash. A Tracker instead keeps a single hash->waiter map and
+// wakes waiters as soon as a Subscriber reports inclusion, only falling
+// back to polling for hashes a Subscriber hasn't confirmed within
+// FallbackAfter.
+package txtracker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Receipt is what a Tracker reports once a transaction is included.
+type Receipt struct {
+	Hash        common.Hash
+	BlockHeight uint64
+}
+
+// Result is one entry of an AwaitN response: either a Receipt or an Err if
+// the wait was cancelled or timed out.
+type Result struct {
+	Hash    common.Hash
+	Receipt Receipt
+	Err     error
+}
+
+// Options configures a single Await/AwaitN call.
+type Options struct {
+	// Confirmations is how many blocks must land on top of the including
+	// block before the waiter is signalled. Zero means "signal as soon as
+	// included".
+	Confirmations uint64
+
+	// FallbackAfter is how long to wait for the Subscriber to report a
+	// hash before falling back to polling it directly. Zero uses the
+	// Tracker's default.
+	FallbackAfter time.Duration
+}
+
+// Subscriber is the push side of the tracker: a feed of newly-included
+// blocks, backed by a node subscription. No implementation exists in this
+// repo; NewPolling builds a Tracker that runs without one.
+type Subscriber interface {
+	// SubscribeBlocks returns a channel of block heights as they're
+	// observed, along with the tx hashes each block included. The channel
+	// is closed when ctx is done.
+	SubscribeBlocks(ctx context.Context) (<-chan Block, error)
+}
+
+// Block is one new-block notification: its height and the hashes of the
+// transactions it included.
+type Block struct {
+	Height uint64
+	Hashes []common.Hash
+}
+
+type waiter struct {
+	confirmations uint64
+	includedAt    uint64 // 0 until seen
+	ch            chan Receipt
+	resolved      chan struct{} // closed once onBlock has delivered to ch
+}
+
+// Tracker dispatches block notifications (and, for hashes the subscriber
+// hasn't reported within FallbackAfter, direct polls) to Await/AwaitN
+// callers.
+type Tracker struct {
+	mu            sync.Mutex
+	waiters       map[common.Hash][]*waiter
+	sub           Subscriber
+	poller        Poller
+	fallbackAfter time.Duration
+	pollInterval  time.Duration
+}
+
+// New builds a Tracker. poller may be nil if no polling fallback is
+// available; sub may be nil to run polling-only (e.g. via NewPolling).
+func New(sub Subscriber, poller Poller, fallbackAfter, pollInterval time.Duration) *Tracker {
+	return &Tracker{
+		waiters:       make(map[common.Hash][]*waiter),
+		sub:           sub,
+		poller:        poller,
+		fallbackAfter: fallbackAfter,
+		pollInterval:  pollInterval,
+	}
+}
+
+// Start begins dispatching block notifications to registered waiters. It
+// blocks until ctx is done, so callers should run it in its own goroutine.
+func (t *Tracker) Start(ctx context.Context) error {
+	if t.sub == nil {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	blocks, err := t.sub.SubscribeBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case block, ok := <-blocks:
+			if !ok {
+				return nil
+			}
+			t.onBlock(block)
+		}
+	}
+}
+
+// Await waits for hash to be included (and confirmed, per opts), returning
+// a channel that receives exactly one Receipt. The channel is never closed
+// without a value; cancel ctx to give up waiting early.
+func (t *Tracker) Await(ctx context.Context, hash common.Hash, opts Options) (<-chan Receipt, error) {
+	ch := make(chan Receipt, 1)
+
+	w := &waiter{confirmations: opts.Confirmations, ch: ch, resolved: make(chan struct{})}
+	t.mu.Lock()
+	t.waiters[hash] = append(t.waiters[hash], w)
+	t.mu.Unlock()
+
+	if t.poller != nil {
+		fallback := opts.FallbackAfter
+		if fallback == 0 {
+			fallback = t.fallbackAfter
+		}
+		go t.pollUntilSeen(ctx, hash, w, fallback)
+	}
+
+	// Without this, a caller awaiting with a long-lived or background
+	// context (e.g. sendtx's mode 1, which never cancels) would leak this
+	// goroutine forever once w resolves, since <-ctx.Done() never fires.
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.removeWaiter(hash, w)
+		case <-w.resolved:
+		}
+	}()
+
+	return ch, nil
+}
+
+// AwaitN is Await for a batch of hashes: each result is delivered to the
+// returned channel independently, as soon as that hash is ready, rather
+// than waiting for the slowest one.
+func (t *Tracker) AwaitN(ctx context.Context, hashes []common.Hash, opts Options) (<-chan Result, error) {
+	out := make(chan Result, len(hashes))
+
+	var wg sync.WaitGroup
+	for _, h := range hashes {
+		h := h
+		ch, err := t.Await(ctx, h, opts)
+		if err != nil {
+			out <- Result{Hash: h, Err: err}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case receipt := <-ch:
+				out <- Result{Hash: h, Receipt: receipt}
+			case <-ctx.Done():
+				out <- Result{Hash: h, Err: ctx.Err()}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+func (t *Tracker) onBlock(block Block) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, hash := range block.Hashes {
+		for _, w := range t.waiters[hash] {
+			if w.includedAt == 0 {
+				w.includedAt = block.Height
+			}
+		}
+	}
+
+	for hash, ws := range t.waiters {
+		remaining := ws[:0]
+		for _, w := range ws {
+			if w.includedAt != 0 && block.Height-w.includedAt >= w.confirmations {
+				w.ch <- Receipt{Hash: hash, BlockHeight: w.includedAt}
+				close(w.resolved)
+				continue
+			}
+			remaining = append(remaining, w)
+		}
+
+		if len(remaining) == 0 {
+			delete(t.waiters, hash)
+		} else {
+			t.waiters[hash] = remaining
+		}
+	}
+}
+
+// pollUntilSeen is the fallback path for a waiter a Subscriber hasn't
+// resolved within fallbackAfter (or the only path at all, for a
+// Subscriber-less Tracker built via NewPolling). It first polls for
+// inclusion, then - since Confirmations needs to know how far the chain has
+// moved since - keeps polling BlockHeight until the waiter is resolved or
+// removed.
+func (t *Tracker) pollUntilSeen(ctx context.Context, hash common.Hash, w *waiter, fallbackAfter time.Duration) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(fallbackAfter):
+	}
+
+	interval := t.pollInterval
+	if interval == 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var shard uint
+	var haveShard bool
+
+	for {
+		if !t.isWaiting(hash, w) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !haveShard {
+				included, foundShard, err := t.poller.IsIncluded(hash)
+				if err != nil || !included {
+					continue
+				}
+				shard = foundShard
+				haveShard = true
+			}
+
+			height, err := t.poller.BlockHeight(shard)
+			if err != nil {
+				continue
+			}
+
+			t.onBlock(Block{Height: height, Hashes: []common.Hash{hash}})
+		}
+	}
+}
+
+func (t *Tracker) isWaiting(hash common.Hash, target *waiter) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, w := range t.waiters[hash] {
+		if w == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Tracker) removeWaiter(hash common.Hash, target *waiter) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ws := t.waiters[hash]
+	remaining := ws[:0]
+	for _, w := range ws {
+		if w != target {
+			remaining = append(remaining, w)
+		}
+	}
+
+	if len(remaining) == 0 {
+		delete(t.waiters, hash)
+	} else {
+		t.waiters[hash] = remaining
+	}
+}
@@ -0,0 +1,193 @@
+package txtracker
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+type fakeSubscriber struct {
+	ch chan Block
+}
+
+func (f *fakeSubscriber) SubscribeBlocks(ctx context.Context) (<-chan Block, error) {
+	return f.ch, nil
+}
+
+func TestTrackerAwaitSignalsOnceConfirmed(t *testing.T) {
+	sub := &fakeSubscriber{ch: make(chan Block, 4)}
+	tracker := New(sub, nil, 0, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.Start(ctx)
+
+	hash := common.Hash{1}
+	ch, err := tracker.Await(ctx, hash, Options{Confirmations: 2})
+	if err != nil {
+		t.Fatalf("Await: %s", err)
+	}
+
+	sub.ch <- Block{Height: 10, Hashes: []common.Hash{hash}}
+	select {
+	case <-ch:
+		t.Fatal("should not signal before any confirmation lands")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sub.ch <- Block{Height: 11}
+	select {
+	case <-ch:
+		t.Fatal("should not signal after only 1 of 2 confirmations")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sub.ch <- Block{Height: 12}
+	select {
+	case r := <-ch:
+		if r.BlockHeight != 10 {
+			t.Fatalf("expected inclusion height 10, got %d", r.BlockHeight)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the confirmation receipt")
+	}
+}
+
+func TestTrackerAwaitNResolvesHashesIndependently(t *testing.T) {
+	sub := &fakeSubscriber{ch: make(chan Block, 4)}
+	tracker := New(sub, nil, 0, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.Start(ctx)
+
+	fast, slow := common.Hash{1}, common.Hash{2}
+	results, err := tracker.AwaitN(ctx, []common.Hash{fast, slow}, Options{})
+	if err != nil {
+		t.Fatalf("AwaitN: %s", err)
+	}
+
+	sub.ch <- Block{Height: 1, Hashes: []common.Hash{fast}}
+
+	select {
+	case r := <-results:
+		if r.Hash != fast {
+			t.Fatalf("expected the included hash to resolve first, got %v", r.Hash)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the fast hash to resolve")
+	}
+
+	sub.ch <- Block{Height: 2, Hashes: []common.Hash{slow}}
+
+	select {
+	case r := <-results:
+		if r.Hash != slow {
+			t.Fatalf("expected the second hash to resolve next, got %v", r.Hash)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the slow hash to resolve")
+	}
+}
+
+// TestAwaitDoesNotLeakCleanupGoroutineOnBackgroundContext guards against a
+// regression where Await's cleanup goroutine parked on <-ctx.Done() forever
+// once a waiter resolved, because a never-cancelled context (e.g.
+// context.Background(), as sendtx's mode 1 uses) never fires Done.
+func TestAwaitDoesNotLeakCleanupGoroutineOnBackgroundContext(t *testing.T) {
+	sub := &fakeSubscriber{ch: make(chan Block, 8)}
+	tracker := New(sub, nil, 0, time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go tracker.Start(ctx)
+
+	before := runtime.NumGoroutine()
+
+	const n = 50
+	for i := 0; i < n; i++ {
+		hash := common.Hash{byte(i), byte(i >> 8)}
+		ch, err := tracker.Await(context.Background(), hash, Options{})
+		if err != nil {
+			t.Fatalf("Await: %s", err)
+		}
+
+		sub.ch <- Block{Height: 1, Hashes: []common.Hash{hash}}
+
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for hash %d to resolve", i)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if runtime.NumGoroutine() <= before+5 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines leaked: started at %d, now at %d", before, runtime.NumGoroutine())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type fakePoller struct {
+	mu       sync.Mutex
+	included bool
+	shard    uint
+	height   uint64
+}
+
+func (f *fakePoller) IsIncluded(common.Hash) (bool, uint, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.included, f.shard, nil
+}
+
+func (f *fakePoller) BlockHeight(shard uint) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if shard != f.shard {
+		return 0, fmt.Errorf("fakePoller: queried shard %d, want %d", shard, f.shard)
+	}
+	return f.height, nil
+}
+
+func (f *fakePoller) setIncluded(shard uint, height uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.included = true
+	f.shard = shard
+	f.height = height
+}
+
+func TestTrackerPollingFallbackResolvesWithNoSubscriber(t *testing.T) {
+	poller := &fakePoller{height: 5}
+	tracker := NewPolling(poller, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ch, err := tracker.Await(ctx, common.Hash{1}, Options{})
+	if err != nil {
+		t.Fatalf("Await: %s", err)
+	}
+
+	poller.setIncluded(3, 6)
+
+	select {
+	case r := <-ch:
+		if r.BlockHeight != 6 {
+			t.Fatalf("expected inclusion height 6, got %d", r.BlockHeight)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the polling fallback to resolve")
+	}
+}
@@ -0,0 +1,73 @@
+package walletpool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Sender submits a signed transfer and reports its hash. It is implemented
+// by the caller (e.g. cmd/tool's rpc client wrapper) so this package doesn't
+// need to depend on rpc.Client directly.
+type Sender interface {
+	Transfer(from *Wallet, nonce uint64, to common.Address, amount *big.Int) (common.Hash, error)
+}
+
+// Faucet refills wallets whose cached balance has dropped below Threshold,
+// replacing the pattern of manually topping up keyfile accounts by hand
+// before a load test.
+type Faucet struct {
+	Address      common.Address
+	Threshold    *big.Int
+	RefillAmount *big.Int
+}
+
+// EnsureFunded tops address up from the faucet if its cached balance is
+// below f.Threshold. It is a no-op if the wallet is already funded. It
+// returns an error if either address or the faucet wallet isn't registered
+// with the pool.
+func (p *Pool) EnsureFunded(address common.Address, f *Faucet, sender Sender) error {
+	p.mu.Lock()
+	w, ok := p.wallets[address]
+	faucet, faucetOK := p.wallets[f.Address]
+	p.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("walletpool: unknown address %s", address.ToHex())
+	}
+	if !faucetOK {
+		return fmt.Errorf("walletpool: faucet wallet %s is not registered", f.Address.ToHex())
+	}
+
+	if w.Balance != nil && w.Balance.Cmp(f.Threshold) >= 0 {
+		return nil
+	}
+
+	nonce, _, err := p.Reserve(f.Address)
+	if err != nil {
+		return fmt.Errorf("walletpool: faucet busy: %s", err)
+	}
+
+	tx, err := sender.Transfer(faucet, nonce, address, f.RefillAmount)
+	if err != nil {
+		p.Release(f.Address, nonce)
+		return fmt.Errorf("walletpool: faucet refill failed: %s", err)
+	}
+
+	p.Confirm(f.Address, nonce, tx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if w.Balance == nil {
+		w.Balance = new(big.Int).Set(f.RefillAmount)
+	} else {
+		w.Balance.Add(w.Balance, f.RefillAmount)
+	}
+
+	if faucet.Balance != nil {
+		faucet.Balance.Sub(faucet.Balance, f.RefillAmount)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package walletpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+type fakeSender struct {
+	hash common.Hash
+	err  error
+}
+
+func (f *fakeSender) Transfer(from *Wallet, nonce uint64, to common.Address, amount *big.Int) (common.Hash, error) {
+	return f.hash, f.err
+}
+
+func TestFaucetEnsureFundedCreditsRecipientAndDebitsFaucet(t *testing.T) {
+	p := New()
+	faucetAddr, recipient := addr(9), addr(1)
+
+	p.Add(faucetAddr, nil, 0, 0, big.NewInt(1000))
+	p.Add(recipient, nil, 0, 0, big.NewInt(0))
+
+	f := &Faucet{Address: faucetAddr, Threshold: big.NewInt(10), RefillAmount: big.NewInt(100)}
+
+	if err := p.EnsureFunded(recipient, f, &fakeSender{hash: common.Hash{1}}); err != nil {
+		t.Fatalf("EnsureFunded: %s", err)
+	}
+
+	byAddr := make(map[common.Address]*Wallet)
+	for _, w := range p.Snapshot() {
+		byAddr[w.Address] = w
+	}
+
+	if got := byAddr[recipient].Balance; got.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expected recipient balance 100, got %s", got)
+	}
+
+	// The faucet's cached balance must drop by the same amount, otherwise it
+	// looks infinitely funded to every future EnsureFunded call.
+	if got := byAddr[faucetAddr].Balance; got.Cmp(big.NewInt(900)) != 0 {
+		t.Fatalf("expected faucet balance debited to 900, got %s", got)
+	}
+}
+
+func TestFaucetEnsureFundedNoopWhenAboveThreshold(t *testing.T) {
+	p := New()
+	faucetAddr, recipient := addr(9), addr(1)
+
+	p.Add(faucetAddr, nil, 0, 0, big.NewInt(1000))
+	p.Add(recipient, nil, 0, 0, big.NewInt(50))
+
+	f := &Faucet{Address: faucetAddr, Threshold: big.NewInt(10), RefillAmount: big.NewInt(100)}
+
+	if err := p.EnsureFunded(recipient, f, &fakeSender{hash: common.Hash{1}}); err != nil {
+		t.Fatalf("EnsureFunded: %s", err)
+	}
+
+	for _, w := range p.Snapshot() {
+		if w.Address == faucetAddr && w.Balance.Cmp(big.NewInt(1000)) != 0 {
+			t.Fatalf("expected faucet balance untouched when recipient is already funded, got %s", w.Balance)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package walletpool
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// shardPool keeps the wallets belonging to a single shard, plus a rotation
+// cursor so Rotate can spread load round-robin without scanning a map.
+type shardPool struct {
+	addresses []common.Address
+	next      int
+}
+
+// Pool is a keyed set of wallets with atomic nonce reservation. All access
+// goes through a single mutex: sendtx's wallet counts are small enough that
+// this is simpler and safer than per-wallet locking. It guards wallet state
+// only; loopSendMode1_2 still copies its balance slice under its own
+// *sync.Mutex.
+type Pool struct {
+	mu      sync.Mutex
+	wallets map[common.Address]*Wallet
+	shards  map[uint]*shardPool
+}
+
+// New returns an empty Pool.
+func New() *Pool {
+	return &Pool{
+		wallets: make(map[common.Address]*Wallet),
+		shards:  make(map[uint]*shardPool),
+	}
+}
+
+// Add registers a wallet with the pool, keyed by address. Re-adding an
+// address replaces its cached state.
+func (p *Pool) Add(address common.Address, key *ecdsa.PrivateKey, shard uint, nonce uint64, balance *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.wallets[address]; !ok {
+		sp, ok := p.shards[shard]
+		if !ok {
+			sp = &shardPool{}
+			p.shards[shard] = sp
+		}
+		sp.addresses = append(sp.addresses, address)
+	}
+
+	p.wallets[address] = &Wallet{
+		Address:    address,
+		PrivateKey: key,
+		Shard:      shard,
+		Nonce:      nonce,
+		Balance:    balance,
+	}
+}
+
+// Reserve atomically takes the next nonce for address and marks the wallet
+// busy, returning its private key so the caller can sign a transaction. The
+// caller must follow up with Confirm on success or Release on failure -
+// otherwise the wallet stays reserved and further Reserve calls for it fail.
+func (p *Pool) Reserve(address common.Address) (uint64, *ecdsa.PrivateKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.wallets[address]
+	if !ok {
+		return 0, nil, fmt.Errorf("walletpool: unknown address %s", address.ToHex())
+	}
+
+	if w.reserved {
+		return 0, nil, fmt.Errorf("walletpool: %s already has a reservation in flight", address.ToHex())
+	}
+
+	w.reserved = true
+	return w.Nonce, w.PrivateKey, nil
+}
+
+// Release rolls back a reservation that was never submitted (e.g. the RPC
+// call failed), so the nonce can be reused instead of being burned.
+func (p *Pool) Release(address common.Address, nonce uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.wallets[address]
+	if !ok || w.Nonce != nonce {
+		return
+	}
+
+	w.reserved = false
+}
+
+// Confirm records a successfully submitted transaction: the nonce advances
+// permanently and the tx hash is added to the wallet's in-flight set until
+// the caller calls Forget (typically once a txtracker observes inclusion).
+func (p *Pool) Confirm(address common.Address, nonce uint64, tx common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.wallets[address]
+	if !ok || w.Nonce != nonce {
+		return
+	}
+
+	w.reserved = false
+	w.Nonce = nonce + 1
+	w.Inflight = append(w.Inflight, tx)
+}
+
+// Forget removes a tx hash from a wallet's in-flight set once it has been
+// observed included (or has been abandoned).
+func (p *Pool) Forget(address common.Address, tx common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.wallets[address]
+	if !ok {
+		return
+	}
+
+	for i, h := range w.Inflight {
+		if h == tx {
+			w.Inflight = append(w.Inflight[:i], w.Inflight[i+1:]...)
+			break
+		}
+	}
+}
+
+// Remove permanently evicts address from the pool, e.g. once its balance is
+// spent down to zero and it will never send or receive again. Any hashes
+// still in its Inflight set should be Forgotten (or simply dropped) by the
+// caller first, since Remove discards them with the wallet.
+func (p *Pool) Remove(address common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.wallets[address]
+	if !ok {
+		return
+	}
+	delete(p.wallets, address)
+
+	sp, ok := p.shards[w.Shard]
+	if !ok {
+		return
+	}
+	for i, a := range sp.addresses {
+		if a == address {
+			sp.addresses = append(sp.addresses[:i], sp.addresses[i+1:]...)
+			break
+		}
+	}
+}
+
+// Rotate returns the next wallet in shard, round-robin, skipping wallets
+// that currently have a reservation in flight. It returns an error if the
+// shard has no wallets or all of them are reserved.
+func (p *Pool) Rotate(shard uint) (*Wallet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sp, ok := p.shards[shard]
+	if !ok || len(sp.addresses) == 0 {
+		return nil, fmt.Errorf("walletpool: no wallets registered for shard %d", shard)
+	}
+
+	for i := 0; i < len(sp.addresses); i++ {
+		addr := sp.addresses[sp.next%len(sp.addresses)]
+		sp.next++
+
+		if w := p.wallets[addr]; w != nil && !w.reserved {
+			return w.clone(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("walletpool: all wallets in shard %d are currently reserved", shard)
+}
+
+// SetBalance updates the cached balance for address, e.g. from a
+// reconciliation pass or after a faucet refill.
+func (p *Pool) SetBalance(address common.Address, balance *big.Int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if w, ok := p.wallets[address]; ok {
+		w.Balance = balance
+	}
+}
+
+// Snapshot returns a point-in-time copy of every wallet in the pool, safe
+// for the caller to read without holding the pool's lock.
+func (p *Pool) Snapshot() []*Wallet {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := make([]*Wallet, 0, len(p.wallets))
+	for _, w := range p.wallets {
+		out = append(out, w.clone())
+	}
+
+	return out
+}
@@ -0,0 +1,126 @@
+package walletpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+func addr(b byte) common.Address {
+	return common.Address{b}
+}
+
+func TestPoolReserveReleaseRoundTrip(t *testing.T) {
+	p := New()
+	a := addr(1)
+	p.Add(a, nil, 0, 5, big.NewInt(100))
+
+	nonce, _, err := p.Reserve(a)
+	if err != nil {
+		t.Fatalf("Reserve: %s", err)
+	}
+	if nonce != 5 {
+		t.Fatalf("expected nonce 5, got %d", nonce)
+	}
+
+	if _, _, err := p.Reserve(a); err == nil {
+		t.Fatal("expected a second Reserve to fail while one is in flight")
+	}
+
+	p.Release(a, nonce)
+
+	if _, _, err := p.Reserve(a); err != nil {
+		t.Fatalf("Reserve after Release: %s", err)
+	}
+}
+
+func TestPoolConfirmAdvancesNonceAndTracksInflight(t *testing.T) {
+	p := New()
+	a := addr(2)
+	p.Add(a, nil, 0, 0, big.NewInt(0))
+
+	nonce, _, err := p.Reserve(a)
+	if err != nil {
+		t.Fatalf("Reserve: %s", err)
+	}
+
+	tx := common.Hash{3}
+	p.Confirm(a, nonce, tx)
+
+	w := p.Snapshot()[0]
+	if w.Nonce != nonce+1 {
+		t.Fatalf("expected nonce to advance to %d, got %d", nonce+1, w.Nonce)
+	}
+	if len(w.Inflight) != 1 || w.Inflight[0] != tx {
+		t.Fatalf("expected tx to be tracked inflight, got %v", w.Inflight)
+	}
+
+	p.Forget(a, tx)
+	w = p.Snapshot()[0]
+	if len(w.Inflight) != 0 {
+		t.Fatalf("expected Forget to clear the inflight set, got %v", w.Inflight)
+	}
+}
+
+func TestPoolRemoveDropsWalletAndShardEntry(t *testing.T) {
+	p := New()
+	a, b := addr(1), addr(2)
+	p.Add(a, nil, 0, 0, big.NewInt(0))
+	p.Add(b, nil, 0, 0, big.NewInt(0))
+
+	p.Remove(a)
+
+	if _, _, err := p.Reserve(a); err == nil {
+		t.Fatal("expected Reserve to fail for a removed address")
+	}
+
+	if len(p.Snapshot()) != 1 {
+		t.Fatalf("expected 1 wallet left after Remove, got %d", len(p.Snapshot()))
+	}
+
+	for i := 0; i < 4; i++ {
+		w, err := p.Rotate(0)
+		if err != nil {
+			t.Fatalf("Rotate: %s", err)
+		}
+		if w.Address != b {
+			t.Fatalf("expected Rotate to only ever return the remaining wallet, got %v", w.Address)
+		}
+	}
+}
+
+func TestPoolRotateSkipsReservedWallets(t *testing.T) {
+	p := New()
+	a, b := addr(1), addr(2)
+	p.Add(a, nil, 0, 0, big.NewInt(0))
+	p.Add(b, nil, 0, 0, big.NewInt(0))
+
+	if _, _, err := p.Reserve(a); err != nil {
+		t.Fatalf("Reserve: %s", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		w, err := p.Rotate(0)
+		if err != nil {
+			t.Fatalf("Rotate: %s", err)
+		}
+		if w.Address != b {
+			t.Fatalf("expected Rotate to only return the unreserved wallet, got %v", w.Address)
+		}
+	}
+}
+
+func TestPoolRotateErrorsWhenAllReserved(t *testing.T) {
+	p := New()
+	a := addr(1)
+	p.Add(a, nil, 0, 0, big.NewInt(0))
+
+	if _, _, err := p.Reserve(a); err != nil {
+		t.Fatalf("Reserve: %s", err)
+	}
+
+	if _, err := p.Rotate(0); err == nil {
+		t.Fatal("expected Rotate to fail once every wallet is reserved")
+	}
+}
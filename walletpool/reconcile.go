@@ -0,0 +1,65 @@
+package walletpool
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// ChainClient is the minimal read-only view of the node the pool needs to
+// reconcile its cached state. cmd/tool adapts its RPC plumbing to this
+// interface (rpcChainClient); LightBackend does not implement it.
+type ChainClient interface {
+	GetNonce(address common.Address) (uint64, error)
+	GetBalance(address common.Address) (*big.Int, error)
+}
+
+// Reconcile refreshes every wallet's cached nonce and balance from client,
+// overwriting the pool's bookkeeping with the node's view. It skips wallets
+// that currently have a reservation in flight so it never clobbers a nonce
+// that's mid-send.
+func (p *Pool) Reconcile(client ChainClient) {
+	for _, w := range p.Snapshot() {
+		if w.reserved {
+			continue
+		}
+
+		nonce, err := client.GetNonce(w.Address)
+		if err != nil {
+			continue
+		}
+
+		balance, err := client.GetBalance(w.Address)
+		if err != nil {
+			continue
+		}
+
+		p.mu.Lock()
+		if live, ok := p.wallets[w.Address]; ok && !live.reserved {
+			live.Nonce = nonce
+			live.Balance = balance
+		}
+		p.mu.Unlock()
+	}
+}
+
+// StartReconciler runs Reconcile every interval until ctx is cancelled. It
+// is meant to be started once in the background (e.g. alongside the sendtx
+// threads) rather than called inline on the hot path.
+func (p *Pool) StartReconciler(ctx context.Context, client ChainClient, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.Reconcile(client)
+			}
+		}
+	}()
+}
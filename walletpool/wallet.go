@@ -0,0 +1,39 @@
+/**
+*  @file
+*  @copyright defined in go-seele/LICENSE
+ */
+
+// Package walletpool owns the wallet lifecycle (keys, nonces, balances, and
+// in-flight transactions) that used to be scattered across cmd/tool's
+// sendtx balance struct, giving callers a single reservation and
+// reconciliation lifecycle instead of reimplementing ad-hoc locking.
+package walletpool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/seeleteam/go-seele/common"
+)
+
+// Wallet is the state the pool tracks for a single address. Callers never
+// get a pointer to the pool's own copy; Snapshot and Rotate return copies so
+// callers can't mutate pool state without going through Reserve/Confirm.
+type Wallet struct {
+	Address    common.Address
+	PrivateKey *ecdsa.PrivateKey
+	Shard      uint
+	Nonce      uint64
+	Balance    *big.Int
+	Inflight   []common.Hash
+	reserved   bool
+}
+
+func (w *Wallet) clone() *Wallet {
+	c := *w
+	c.Inflight = append([]common.Hash(nil), w.Inflight...)
+	if w.Balance != nil {
+		c.Balance = new(big.Int).Set(w.Balance)
+	}
+	return &c
+}